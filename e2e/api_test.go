@@ -6,17 +6,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/fprojetto/pokedex-api/internal/api-client/webhook"
 	"github.com/fprojetto/pokedex-api/internal/application"
 	"github.com/fprojetto/pokedex-api/internal/config"
+	"github.com/fprojetto/pokedex-api/pkg/pokedexpb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 )
 
 type PokemonResponse struct {
@@ -28,7 +38,15 @@ type PokemonResponse struct {
 	} `json:"data"`
 }
 
-const appPort = "9091"
+const (
+	appPort    = "9091"
+	grpcPort   = "9092"
+	opsPort    = "9096"
+	adminToken = "e2e-admin-token"
+	debugToken = "e2e-debug-token"
+)
+
+var configFile = filepath.Join(os.TempDir(), "pokedex-api-e2e.yaml")
 
 func TestMain(m *testing.M) {
 	// 1. Setup Mock Servers
@@ -41,11 +59,35 @@ func TestMain(m *testing.M) {
 	os.Setenv("POKEMON_API_URL", mockPokeAPI.URL)
 	os.Setenv("TRANSLATION_API_URL", mockFunTranslationsAPI.URL)
 	os.Setenv("PORT", appPort) // Random available port
+	os.Setenv("GRPC_PORT", grpcPort)
+	os.Setenv("OPS_PORT", opsPort)
+	os.Setenv("ADMIN_TOKEN", adminToken)
+	// the webhook receivers of the tests run on localhost
+	os.Setenv("WEBHOOK_ALLOW_PRIVATE_URLS", "true")
+	os.Setenv("DEBUG_ENABLED", "true")
+	os.Setenv("DEBUG_TOKEN", debugToken)
+	os.Setenv("CONFIG_FILE", configFile)
 	defer os.Unsetenv("POKEMON_API_URL")
 	defer os.Unsetenv("TRANSLATION_API_URL")
 	defer os.Unsetenv("PORT")
+	defer os.Unsetenv("GRPC_PORT")
+	defer os.Unsetenv("OPS_PORT")
+	defer os.Unsetenv("ADMIN_TOKEN")
+	defer os.Unsetenv("WEBHOOK_ALLOW_PRIVATE_URLS")
+	defer os.Unsetenv("DEBUG_ENABLED")
+	defer os.Unsetenv("DEBUG_TOKEN")
+	defer os.Unsetenv("CONFIG_FILE")
+
+	if err := os.WriteFile(configFile, []byte("cache:\n  ttl: 10m\n"), 0o600); err != nil {
+		log.Printf("failed to write configuration file: %v\n", err)
+		os.Exit(1)
+	}
+	defer os.Remove(configFile)
 
-	cfg, err := config.New()
+	load := func() (*config.Config, error) {
+		return config.Load(nil, os.Getenv)
+	}
+	cfg, err := load()
 	if err != nil {
 		log.Printf("failed to start application: %v\n", err)
 		os.Exit(1)
@@ -55,7 +97,7 @@ func TestMain(m *testing.M) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	go func() {
-		if err := application.Run(ctx, *cfg); err != nil && err != http.ErrServerClosed {
+		if err := application.Run(ctx, *cfg, load); err != nil && err != http.ErrServerClosed {
 			log.Printf("App stopped with error: %v\n", err)
 		} else {
 			log.Println("App stopped")
@@ -63,7 +105,7 @@ func TestMain(m *testing.M) {
 	}()
 
 	// Wait for app to start
-	if appStarted := waitForApp(appPort); !appStarted {
+	if appStarted := waitForApp(opsPort); !appStarted {
 		log.Printf("Application failed to start\n")
 		os.Exit(1)
 	}
@@ -115,6 +157,266 @@ func TestE2EInProcess(t *testing.T) {
 	})
 }
 
+func TestE2EOpsListener(t *testing.T) {
+	tests := []struct {
+		name           string
+		port           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "health on the ops port", port: opsPort, path: "/health", expectedStatus: http.StatusOK},
+		{name: "no health on the api port", port: appPort, path: "/health", expectedStatus: http.StatusNotFound},
+		{name: "no api on the ops port", port: opsPort, path: "/api/pokemon/mentwo", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(fmt.Sprintf("http://localhost:%s%s", tt.port, tt.path))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestE2EDebug(t *testing.T) {
+	// an upstream connection to report
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%s/api/pokemon/mentwo", appPort))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	get := func(t *testing.T, port, path, token string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%s%s", port, path), nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	tests := []struct {
+		name           string
+		port           string
+		path           string
+		token          string
+		expectedStatus int
+	}{
+		{name: "profiles", port: opsPort, path: "/debug/pprof/", token: debugToken, expectedStatus: http.StatusOK},
+		{name: "heap profile", port: opsPort, path: "/debug/pprof/heap", token: debugToken, expectedStatus: http.StatusOK},
+		{name: "no command line", port: opsPort, path: "/debug/pprof/cmdline", token: debugToken, expectedStatus: http.StatusNotFound},
+		{name: "no token", port: opsPort, path: "/debug/vars", expectedStatus: http.StatusUnauthorized},
+		{name: "invalid token", port: opsPort, path: "/debug/pprof/", token: adminToken, expectedStatus: http.StatusUnauthorized},
+		{name: "not on the api port", port: appPort, path: "/debug/vars", token: debugToken, expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedStatus, get(t, tt.port, tt.path, tt.token).StatusCode)
+		})
+	}
+
+	t.Run("vars", func(t *testing.T) {
+		resp := get(t, opsPort, "/debug/vars", debugToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var vars struct {
+			Runtime struct {
+				Goroutines int `json:"goroutines"`
+				GC         struct {
+					Cycles uint32 `json:"cycles"`
+				} `json:"gc"`
+				Heap struct {
+					AllocBytes uint64 `json:"alloc_bytes"`
+				} `json:"heap"`
+			} `json:"runtime"`
+			UpstreamConnections map[string]int `json:"upstream_connections"`
+			ConfigReloads       map[string]int `json:"config_reloads"`
+			Cmdline             []string       `json:"cmdline"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&vars))
+		assert.Positive(t, vars.Runtime.Goroutines)
+		assert.Positive(t, vars.Runtime.Heap.AllocBytes)
+		assert.NotNil(t, vars.ConfigReloads)
+		assert.Nil(t, vars.Cmdline)
+
+		var open int
+		for _, n := range vars.UpstreamConnections {
+			open += n
+		}
+		assert.Positive(t, open, "the connections to the mock upstreams are kept alive")
+	})
+}
+
+func TestE2ETranslationJob(t *testing.T) {
+	// a webhook receiving the signed event of the job
+	events := make(chan webhook.Payload, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		if r.Header.Get(webhook.HeaderSignature) != webhook.Sign("e2e-secret", timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var payload webhook.Payload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		events <- payload
+	}))
+	defer receiver.Close()
+
+	subscribe := func(token string) *http.Response {
+		subscription := strings.NewReader(fmt.Sprintf(`{"url": %q, "events": ["job.completed"], "secret": "e2e-secret"}`, receiver.URL))
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://localhost:%s/api/webhooks", appPort), subscription)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Failed to send request to API")
+		resp.Body.Close()
+		return resp
+	}
+	require.Equal(t, http.StatusUnauthorized, subscribe("guess").StatusCode)
+	resp := subscribe(adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	webhookURL := "http://localhost:" + appPort + resp.Header.Get("Location")
+	defer func() {
+		req, _ := http.NewRequest("DELETE", webhookURL, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		if resp, err := http.DefaultClient.Do(req); err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	body := strings.NewReader(`{"names": ["mentwo"], "style": "yoda"}`)
+	resp, err := http.Post(fmt.Sprintf("http://localhost:%s/api/jobs/translations", appPort), "application/json", body)
+	require.NoError(t, err, "Failed to send request to API")
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	type jobResponse struct {
+		Data struct {
+			Status  string `json:"status"`
+			Results []struct {
+				Pokemon struct {
+					Description string `json:"description"`
+				} `json:"pokemon"`
+			} `json:"results"`
+		} `json:"data"`
+	}
+
+	var job jobResponse
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://localhost:" + appPort + resp.Header.Get("Location"))
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return json.NewDecoder(resp.Body).Decode(&job) == nil && job.Data.Status == "completed"
+	}, 2*time.Second, 20*time.Millisecond)
+
+	require.Len(t, job.Data.Results, 1)
+	assert.Equal(t, "Created by a scientist after years of horrific gene splicing and dna engineering experiments, it was.", job.Data.Results[0].Pokemon.Description)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "job.completed", event.Type)
+		assert.Equal(t, resp.Header.Get("Location"), event.Data.(map[string]any)["url"])
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook event not received")
+	}
+}
+
+func TestE2EReloadConfig(t *testing.T) {
+	reload := func(token string) (*http.Response, map[string]any) {
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://localhost:%s/api/admin/config/reload", appPort), nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Failed to send request to API")
+		defer resp.Body.Close()
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp, body
+	}
+	defer os.WriteFile(configFile, []byte("cache:\n  ttl: 10m\n"), 0o600)
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		resp, _ := reload("guess")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+	t.Run("Reloaded", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configFile, []byte("cache:\n  ttl: 1m\njobs:\n  workers: 3\n"), 0o600))
+
+		resp, body := reload(adminToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		data := body["data"].(map[string]any)
+		assert.Equal(t, []any{"cache.ttl"}, data["applied"])
+		assert.Equal(t, []any{"jobs.workers"}, data["restartRequired"])
+	})
+	t.Run("Invalid configuration", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configFile, []byte("cache:\n  ttl: soon\n"), 0o600))
+
+		resp, body := reload(adminToken)
+		require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		details := body["error"].(map[string]any)["details"].(map[string]any)
+		assert.Equal(t, []any{"invalid cache.ttl in " + configFile + ": must be a non-negative duration"}, details["errors"])
+	})
+	t.Run("Counted", func(t *testing.T) {
+		req, err := http.NewRequest("GET", fmt.Sprintf("http://localhost:%s/api/admin/config/reloads", appPort), nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Failed to send request to API")
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		data := body["data"].(map[string]any)
+		assert.GreaterOrEqual(t, data["succeeded"], float64(1))
+		assert.GreaterOrEqual(t, data["failed"], float64(1))
+		assert.NotEmpty(t, data["lastReloadedAt"])
+	})
+}
+
+func TestE2EGRPC(t *testing.T) {
+	conn, err := grpc.NewClient("localhost:"+grpcPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err, "Failed to create gRPC client")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("Health", func(t *testing.T) {
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	})
+	t.Run("Get Pokemon Translated", func(t *testing.T) {
+		var header metadata.MD
+		callCtx := metadata.AppendToOutgoingContext(ctx, "x-request-id", "e2e-request")
+
+		pokemon, err := pokedexpb.NewPokedexServiceClient(conn).GetPokemonTranslated(
+			callCtx,
+			&pokedexpb.GetPokemonRequest{Name: "mentwo"},
+			grpc.Header(&header),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "mentwo", pokemon.GetName())
+		assert.Equal(t, "Created by a scientist after years of horrific gene splicing and dna engineering experiments, it was.", pokemon.GetDescription())
+		assert.True(t, pokemon.GetIsLegendary())
+		assert.Equal(t, []string{"e2e-request"}, header.Get("x-request-id"))
+	})
+}
+
 func runMockPokeAPI() *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/api/v2/pokemon-species/mentwo" {
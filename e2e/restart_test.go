@@ -0,0 +1,130 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	restartGRPCPort = "9095"
+	restartOpsPort  = "9097"
+)
+
+// TestE2EGracefulRestart runs the application, on a unix socket, as a process of its own to restart it.
+func TestE2EGracefulRestart(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "pokedex-api")
+	build := exec.Command("go", "build", "-o", bin, "github.com/fprojetto/pokedex-api/internal/cmd/api")
+	build.Stderr = os.Stderr
+	require.NoError(t, build.Run())
+
+	socket := filepath.Join(dir, "api.sock")
+	logs, logsWriter, err := os.Pipe()
+	require.NoError(t, err)
+	defer logs.Close()
+
+	cmd := exec.Command(bin)
+	cmd.Env = append(os.Environ(),
+		"SOCKET="+socket,
+		"SOCKET_MODE=0600",
+		"GRPC_PORT="+restartGRPCPort,
+		"OPS_PORT="+restartOpsPort,
+		"GRACEFUL_RESTART=true",
+		"CONFIG_FILE=",
+	)
+	// shared with the new process
+	cmd.Stdout, cmd.Stderr = logsWriter, logsWriter
+	require.NoError(t, cmd.Start())
+	logsWriter.Close()
+
+	restarted := make(chan int, 1)
+	go func() {
+		pattern := regexp.MustCompile(`restarted as process (\d+)`)
+		scanner := bufio.NewScanner(logs)
+		for scanner.Scan() {
+			if m := pattern.FindStringSubmatch(scanner.Text()); m != nil {
+				pid, _ := strconv.Atoi(m[1])
+				restarted <- pid
+			}
+		}
+	}()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+		// a connection per request, so that each one tells whether a process accepts connections
+		DisableKeepAlives: true,
+	}}
+	served := func() bool {
+		resp, err := client.Get("http://pokedex/api/pokemon/mentwo")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}
+	require.Eventually(t, served, 5*time.Second, 50*time.Millisecond)
+
+	fi, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+
+	// requests keep being sent all along the restart
+	var sent, failed atomic.Int64
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			sent.Add(1)
+			if !served() {
+				failed.Add(1)
+			}
+		}
+	}()
+
+	require.NoError(t, cmd.Process.Signal(syscall.SIGUSR2))
+	var pid int
+	select {
+	case pid = <-restarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the application did not restart")
+	}
+	t.Cleanup(func() {
+		_ = syscall.Kill(pid, syscall.SIGTERM)
+		assert.Eventually(t, func() bool { return syscall.Kill(pid, 0) != nil }, 5*time.Second, 50*time.Millisecond)
+	})
+	require.NoError(t, cmd.Wait(), "the previous process must stop gracefully")
+
+	// the new process serves alone
+	time.Sleep(200 * time.Millisecond)
+	close(stop)
+	<-done
+
+	assert.Zero(t, failed.Load(), "%d of %d requests failed", failed.Load(), sent.Load())
+	assert.True(t, served())
+	_, err = os.Stat(socket)
+	assert.NoError(t, err, "the socket must outlive the previous process")
+}
@@ -0,0 +1,176 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tlsPort = "9093"
+
+func TestE2ETLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, ca.certPEM, 0o600))
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	ca.issueFiles(t, serverCertificate("localhost"), certFile, keyFile)
+
+	whoami := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := server.ClientIdentityFromContext(r.Context())
+		_ = json.NewEncoder(w).Encode(id)
+	})
+	srv, err := server.NewHTTPServer(server.ServerConfig{
+		Addr:            "localhost:" + tlsPort,
+		ShutdownTimeout: time.Second,
+		TLS: server.TLSConfig{
+			CertFile:     certFile,
+			KeyFile:      keyFile,
+			MinVersion:   tls.VersionTLS13,
+			ClientCAFile: caFile,
+		},
+	}, whoami)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- srv.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+
+	clientCert := ca.issue(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "e2e-client", Organization: []string{"Pokedex"}},
+		URIs:        []*url.URL{{Scheme: "spiffe", Host: "pokedex", Path: "/e2e"}},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	// a new client for each request, as the server certificate is only reloaded for new connections
+	newClient := func(cfg *tls.Config) *http.Client {
+		cfg.RootCAs = x509.NewCertPool()
+		cfg.RootCAs.AppendCertsFromPEM(ca.certPEM)
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: cfg, ForceAttemptHTTP2: true}}
+	}
+	whoamiURL := "https://localhost:" + tlsPort + "/api/whoami"
+
+	t.Run("client certificate", func(t *testing.T) {
+		resp, err := newClient(&tls.Config{Certificates: []tls.Certificate{clientCert}}).Get(whoamiURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, resp.ProtoMajor)
+		var id server.ClientIdentity
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&id))
+		assert.Equal(t, "e2e-client", id.CommonName)
+		assert.Equal(t, "CN=e2e-client,O=Pokedex", id.Subject)
+		assert.Equal(t, []string{"spiffe://pokedex/e2e"}, id.URIs)
+	})
+	t.Run("no client certificate", func(t *testing.T) {
+		_, err := newClient(&tls.Config{}).Get(whoamiURL)
+		assert.Error(t, err)
+	})
+	t.Run("tls version below the minimum", func(t *testing.T) {
+		_, err := newClient(&tls.Config{Certificates: []tls.Certificate{clientCert}, MaxVersion: tls.VersionTLS12}).Get(whoamiURL)
+		assert.ErrorContains(t, err, "protocol version")
+	})
+	t.Run("certificate reloaded", func(t *testing.T) {
+		ca.issueFiles(t, serverCertificate("renewed"), certFile, keyFile)
+
+		resp, err := newClient(&tls.Config{Certificates: []tls.Certificate{clientCert}}).Get(whoamiURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, "renewed", resp.TLS.PeerCertificates[0].Subject.CommonName)
+	})
+}
+
+// testCA issues the certificates of the tests.
+type testCA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+}
+
+func newTestCA(t *testing.T) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "pokedex e2e CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return testCA{cert: cert, key: key, certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issuePEM returns the PEM certificate and key of template, signed by the CA.
+func (ca testCA) issuePEM(t *testing.T, template *x509.Certificate) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func (ca testCA) issue(t *testing.T, template *x509.Certificate) tls.Certificate {
+	certPEM, keyPEM := ca.issuePEM(t, template)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return cert
+}
+
+func (ca testCA) issueFiles(t *testing.T, template *x509.Certificate, certFile, keyFile string) {
+	certPEM, keyPEM := ca.issuePEM(t, template)
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+}
+
+// serverCertificate is the template of a certificate for localhost, named commonName.
+func serverCertificate(commonName string) *x509.Certificate {
+	return &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonName},
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+}
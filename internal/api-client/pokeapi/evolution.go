@@ -0,0 +1,125 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type EvolutionChainResponse struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+type ChainLink struct {
+	Species          NamedAPIResource  `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+type EvolutionDetail struct {
+	Trigger            NamedAPIResource  `json:"trigger"`
+	MinLevel           *int              `json:"min_level"`
+	Item               *NamedAPIResource `json:"item"`
+	HeldItem           *NamedAPIResource `json:"held_item"`
+	MinHappiness       *int              `json:"min_happiness"`
+	MinAffection       *int              `json:"min_affection"`
+	MinBeauty          *int              `json:"min_beauty"`
+	TimeOfDay          string            `json:"time_of_day"`
+	KnownMove          *NamedAPIResource `json:"known_move"`
+	KnownMoveType      *NamedAPIResource `json:"known_move_type"`
+	Location           *NamedAPIResource `json:"location"`
+	TradeSpecies       *NamedAPIResource `json:"trade_species"`
+	NeedsOverworldRain bool              `json:"needs_overworld_rain"`
+}
+
+// EvolutionChain returns the evolution tree the named species belongs to, following
+// the evolution_chain reference of the species resource.
+func (c *PokemonClient) EvolutionChain(ctx context.Context, name string) (model.EvolutionChain, error) {
+	species, err := c.species(ctx, name)
+	if err != nil {
+		return model.EvolutionChain{}, err
+	}
+	if species.EvolutionChain == nil || species.EvolutionChain.URL == "" {
+		return model.EvolutionChain{}, service.ErrMissingData
+	}
+
+	// the reference is an absolute pokeapi URL: only its path is kept so that the configured base URL is honored
+	chainURL, err := url.Parse(species.EvolutionChain.URL)
+	if err != nil {
+		return model.EvolutionChain{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.URL()+chainURL.EscapedPath(), nil)
+	if err != nil {
+		return model.EvolutionChain{}, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return model.EvolutionChain{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return model.EvolutionChain{}, service.ErrServiceUnavailable
+	}
+
+	var chain EvolutionChainResponse
+	if err := json.NewDecoder(res.Body).Decode(&chain); err != nil {
+		return model.EvolutionChain{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+
+	return model.EvolutionChain{
+		ID:    chain.ID,
+		Chain: mapChainLink(chain.Chain),
+	}, nil
+}
+
+func mapChainLink(link ChainLink) model.EvolutionNode {
+	node := model.EvolutionNode{Name: link.Species.Name}
+	for _, d := range link.EvolutionDetails {
+		node.Conditions = append(node.Conditions, mapEvolutionDetail(d))
+	}
+	for _, next := range link.EvolvesTo {
+		node.EvolvesTo = append(node.EvolvesTo, mapChainLink(next))
+	}
+	return node
+}
+
+func mapEvolutionDetail(d EvolutionDetail) model.EvolutionCondition {
+	return model.EvolutionCondition{
+		Trigger:            d.Trigger.Name,
+		MinLevel:           intValue(d.MinLevel),
+		Item:               resourceName(d.Item),
+		HeldItem:           resourceName(d.HeldItem),
+		MinHappiness:       intValue(d.MinHappiness),
+		MinAffection:       intValue(d.MinAffection),
+		MinBeauty:          intValue(d.MinBeauty),
+		TimeOfDay:          d.TimeOfDay,
+		KnownMove:          resourceName(d.KnownMove),
+		KnownMoveType:      resourceName(d.KnownMoveType),
+		Location:           resourceName(d.Location),
+		TradeSpecies:       resourceName(d.TradeSpecies),
+		NeedsOverworldRain: d.NeedsOverworldRain,
+	}
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+func resourceName(r *NamedAPIResource) string {
+	if r == nil {
+		return ""
+	}
+	return r.Name
+}
@@ -0,0 +1,129 @@
+package pokeapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api-client/pokeapi"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eeveeSpecies = `{
+	"id": 133,
+	"name": "eevee",
+	"evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/67/"}
+}`
+
+const eeveeChain = `{
+	"id": 67,
+	"chain": {
+		"species": {"name": "eevee"},
+		"evolution_details": [],
+		"evolves_to": [
+			{
+				"species": {"name": "vaporeon"},
+				"evolution_details": [
+					{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}, "min_level": null, "time_of_day": ""}
+				],
+				"evolves_to": []
+			},
+			{
+				"species": {"name": "espeon"},
+				"evolution_details": [
+					{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}
+				],
+				"evolves_to": []
+			}
+		]
+	}
+}`
+
+func TestEvolutionChain(t *testing.T) {
+	tests := []struct {
+		name           string
+		speciesStatus  int
+		speciesBody    string
+		chainStatus    int
+		expectedResult model.EvolutionChain
+		expectedError  error
+	}{
+		{
+			name:          "branching evolutions",
+			speciesStatus: http.StatusOK,
+			speciesBody:   eeveeSpecies,
+			chainStatus:   http.StatusOK,
+			expectedResult: model.EvolutionChain{
+				ID: 67,
+				Chain: model.EvolutionNode{
+					Name: "eevee",
+					EvolvesTo: []model.EvolutionNode{
+						{
+							Name:       "vaporeon",
+							Conditions: []model.EvolutionCondition{{Trigger: "use-item", Item: "water-stone"}},
+						},
+						{
+							Name:       "espeon",
+							Conditions: []model.EvolutionCondition{{Trigger: "level-up", MinHappiness: 160, TimeOfDay: "day"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "species not found",
+			speciesStatus: http.StatusNotFound,
+			speciesBody:   `{}`,
+			expectedError: service.ErrNotFound,
+		},
+		{
+			name:          "species without chain",
+			speciesStatus: http.StatusOK,
+			speciesBody:   `{"id": 133, "name": "eevee"}`,
+			expectedError: service.ErrMissingData,
+		},
+		{
+			name:          "chain api error",
+			speciesStatus: http.StatusOK,
+			speciesBody:   eeveeSpecies,
+			chainStatus:   http.StatusInternalServerError,
+			expectedError: service.ErrServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v2/pokemon-species/eevee":
+					w.WriteHeader(tt.speciesStatus)
+					w.Write([]byte(tt.speciesBody))
+				case "/api/v2/evolution-chain/67/":
+					w.WriteHeader(tt.chainStatus)
+					w.Write([]byte(eeveeChain))
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer ts.Close()
+
+			client, err := pokeapi.NewClient(ts.URL, client.DefaultConfig())
+			require.NoError(t, err, "Failed to create pokeapi client")
+
+			result, err := client.EvolutionChain(context.Background(), "eevee")
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
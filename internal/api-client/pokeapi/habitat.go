@@ -0,0 +1,55 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type HabitatResponse struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	PokemonSpecies []NamedAPIResource `json:"pokemon_species"`
+}
+
+// HabitatList lists the pokemon habitats, e.g. "cave" or "waters-edge".
+func (c *PokemonClient) HabitatList(ctx context.Context, limit, offset int) (model.NamePage, error) {
+	return c.namedResourcePage(ctx, "pokemon-habitat", limit, offset)
+}
+
+// HabitatMembers returns the names of every species living in habitat.
+func (c *PokemonClient) HabitatMembers(ctx context.Context, habitat string) ([]string, error) {
+	habitatURL := fmt.Sprintf("%s/api/v2/pokemon-habitat/%s", c.URL(), url.PathEscape(habitat))
+	req, err := http.NewRequestWithContext(ctx, "GET", habitatURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Join(err, service.ErrServiceUnavailable)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		switch res.StatusCode {
+		case http.StatusNotFound:
+			return nil, service.ErrHabitatNotFound
+		default:
+			return nil, service.ErrServiceUnavailable
+		}
+	}
+
+	var h HabitatResponse
+	if err := json.NewDecoder(res.Body).Decode(&h); err != nil {
+		return nil, errors.Join(err, service.ErrServiceUnavailable)
+	}
+
+	return resourceNames(h.PokemonSpecies), nil
+}
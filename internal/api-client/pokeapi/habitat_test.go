@@ -0,0 +1,89 @@
+package pokeapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api-client/pokeapi"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHabitatList(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pokemon-habitat", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		assert.Equal(t, "0", r.URL.Query().Get("offset"))
+		w.Write([]byte(`{"count": 9, "results": [{"name": "cave"}, {"name": "forest"}]}`))
+	}))
+	defer ts.Close()
+
+	client, err := pokeapi.NewClient(ts.URL, client.DefaultConfig())
+	require.NoError(t, err, "Failed to create pokeapi client")
+
+	result, err := client.HabitatList(context.Background(), 2, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, model.NamePage{Names: []string{"cave", "forest"}, Limit: 2, Total: 9}, result)
+}
+
+func TestHabitatMembers(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockStatus     int
+		mockBody       string
+		expectedResult []string
+		expectedError  error
+	}{
+		{
+			name:       "successful retrieval",
+			mockStatus: http.StatusOK,
+			mockBody: `{
+				"id": 1,
+				"name": "cave",
+				"pokemon_species": [{"name": "zubat"}, {"name": "golbat"}]
+			}`,
+			expectedResult: []string{"zubat", "golbat"},
+		},
+		{
+			name:          "habitat not found",
+			mockStatus:    http.StatusNotFound,
+			mockBody:      `{}`,
+			expectedError: service.ErrHabitatNotFound,
+		},
+		{
+			name:          "api error",
+			mockStatus:    http.StatusInternalServerError,
+			mockBody:      `{}`,
+			expectedError: service.ErrServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/pokemon-habitat/cave", r.URL.Path)
+				w.WriteHeader(tt.mockStatus)
+				w.Write([]byte(tt.mockBody))
+			}))
+			defer ts.Close()
+
+			client, err := pokeapi.NewClient(ts.URL, client.DefaultConfig())
+			require.NoError(t, err, "Failed to create pokeapi client")
+
+			result, err := client.HabitatMembers(context.Background(), "cave")
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync/atomic"
 
 	"github.com/fprojetto/pokedex-api/internal/model"
 	"github.com/fprojetto/pokedex-api/internal/service"
@@ -19,6 +21,11 @@ type PokemonSpeciesResponse struct {
 	Habitat           string            `json:"habitat"`
 	IsLegendary       *bool             `json:"is_legendary"`
 	FlavorTextEntries []FlavorTextEntry `json:"flavor_text_entries"`
+	EvolutionChain    *APIResource      `json:"evolution_chain"`
+}
+
+type APIResource struct {
+	URL string `json:"url"`
 }
 
 type FlavorTextEntry struct {
@@ -29,56 +36,226 @@ type FlavorTextEntry struct {
 	} `json:"language"`
 }
 
+// PokemonResponse is the subset of the pokeapi pokemon resource holding the battle data.
+type PokemonResponse struct {
+	ID        int              `json:"id"`
+	Name      string           `json:"name"`
+	Height    int              `json:"height"`
+	Weight    int              `json:"weight"`
+	Types     []PokemonType    `json:"types"`
+	Stats     []PokemonStat    `json:"stats"`
+	Abilities []PokemonAbility `json:"abilities"`
+	Sprites   PokemonSprites   `json:"sprites"`
+}
+
+type PokemonSprites struct {
+	FrontDefault *string `json:"front_default"`
+	FrontShiny   *string `json:"front_shiny"`
+	Other        struct {
+		OfficialArtwork struct {
+			FrontDefault *string `json:"front_default"`
+		} `json:"official-artwork"`
+	} `json:"other"`
+}
+
+type PokemonType struct {
+	Slot int              `json:"slot"`
+	Type NamedAPIResource `json:"type"`
+}
+
+type PokemonStat struct {
+	BaseStat int              `json:"base_stat"`
+	Effort   int              `json:"effort"`
+	Stat     NamedAPIResource `json:"stat"`
+}
+
+type PokemonAbility struct {
+	Slot     int              `json:"slot"`
+	IsHidden bool             `json:"is_hidden"`
+	Ability  NamedAPIResource `json:"ability"`
+}
+
+// NamedAPIResourceList is the paginated list returned by the pokeapi listing endpoints.
+type NamedAPIResourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+}
+
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
 type PokemonClient struct {
-	pokeAPIURL string
+	// pokeAPIURL can be changed while requests are made, see SetURL.
+	pokeAPIURL atomic.Pointer[string]
 	client     *http.Client
 }
 
-func NewClient(pokeAPIURL string) (*PokemonClient, error) {
+func NewClient(pokeAPIURL string, cfg client.Config) (*PokemonClient, error) {
+	c := &PokemonClient{client: client.HttpClient(cfg)}
+	if err := c.SetURL(pokeAPIURL); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CheckURL returns the error SetURL would return for pokeAPIURL.
+func CheckURL(pokeAPIURL string) error {
 	if pokeAPIURL == "" {
-		return nil, errors.New("pokeAPIURL empty string")
+		return errors.New("pokeAPIURL empty string")
+	}
+	return nil
+}
+
+// SetURL changes the base url of the PokeAPI for the requests made from now on.
+func (c *PokemonClient) SetURL(pokeAPIURL string) error {
+	if err := CheckURL(pokeAPIURL); err != nil {
+		return err
+	}
+	c.pokeAPIURL.Store(&pokeAPIURL)
+	return nil
+}
+
+// URL returns the base url of the PokeAPI.
+func (c *PokemonClient) URL() string {
+	return *c.pokeAPIURL.Load()
+}
+
+// PokemonInfo returns the species information of the pokemon. The sections included by opts
+// are loaded concurrently from the pokemon endpoint: when that fails the species information
+// is still returned, with the sections listed as unavailable.
+func (c *PokemonClient) PokemonInfo(ctx context.Context, name string, opts service.PokemonOptions) (model.Pokemon, error) {
+	include := opts.Include
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type battleResult struct {
+		res PokemonResponse
+		err error
 	}
+	battleCh := make(chan battleResult, 1)
+	if include.Any() {
+		go func() {
+			res, err := c.battleInfo(ctx, name)
+			battleCh <- battleResult{res: res, err: err}
+		}()
+	}
+
+	p, err := c.speciesInfo(ctx, name)
+	if err != nil {
+		return model.Pokemon{}, err
+	}
+
+	if include.Any() {
+		battle := <-battleCh
+		if battle.err != nil {
+			p.Unavailable = include.Names()
+		} else {
+			mergeBattleInfo(&p, battle.res, include)
+		}
+	}
+
+	return p, nil
+}
 
-	return &PokemonClient{
-		pokeAPIURL: pokeAPIURL,
-		client:     client.HttpClient(),
+func (c *PokemonClient) speciesInfo(ctx context.Context, name string) (model.Pokemon, error) {
+	species, err := c.species(ctx, name)
+	if err != nil {
+		return model.Pokemon{}, err
+	}
+
+	if species.IsLegendary == nil {
+		return model.Pokemon{}, service.ErrMissingData
+	}
+
+	engDesc := tryToFindEnglishDescription(species.FlavorTextEntries)
+
+	return model.Pokemon{
+		ID:           species.ID,
+		Name:         species.Name,
+		Description:  engDesc,
+		Descriptions: descriptionsByLanguage(species.FlavorTextEntries),
+		Habitat:      species.Habitat,
+		IsLegendary:  species.IsLegendary,
 	}, nil
 }
 
-func (c *PokemonClient) PokemonInfo(ctx context.Context, name string) (model.Pokemon, error) {
+// species fetches the raw species resource.
+func (c *PokemonClient) species(ctx context.Context, name string) (PokemonSpeciesResponse, error) {
 	res, err := c.getBasicInfo(ctx, name)
 	if err != nil {
-		return model.Pokemon{}, errors.Join(err, service.ErrServiceUnavailable)
+		return PokemonSpeciesResponse{}, errors.Join(err, service.ErrServiceUnavailable)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
 		switch res.StatusCode {
 		case http.StatusNotFound:
-			return model.Pokemon{}, service.ErrNotFound
+			return PokemonSpeciesResponse{}, service.ErrNotFound
 		default:
-			return model.Pokemon{}, service.ErrServiceUnavailable
-
+			return PokemonSpeciesResponse{}, service.ErrServiceUnavailable
 		}
 	}
 
 	var species PokemonSpeciesResponse
 	if err := json.NewDecoder(res.Body).Decode(&species); err != nil {
-		return model.Pokemon{}, errors.Join(err, service.ErrServiceUnavailable)
+		return PokemonSpeciesResponse{}, errors.Join(err, service.ErrServiceUnavailable)
 	}
 
-	if species.IsLegendary == nil {
-		return model.Pokemon{}, service.ErrMissingData
+	return species, nil
+}
+
+func (c *PokemonClient) battleInfo(ctx context.Context, name string) (PokemonResponse, error) {
+	getPokemonURL := fmt.Sprintf("%s/api/v2/pokemon/%s", c.URL(), url.PathEscape(name))
+	req, err := http.NewRequestWithContext(ctx, "GET", getPokemonURL, nil)
+	if err != nil {
+		return PokemonResponse{}, err
 	}
 
-	engDesc := tryToFindEnglishDescription(species.FlavorTextEntries)
+	res, err := c.client.Do(req)
+	if err != nil {
+		return PokemonResponse{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+	defer res.Body.Close()
 
-	return model.Pokemon{
-		Name:        species.Name,
-		Description: engDesc,
-		Habitat:     species.Habitat,
-		IsLegendary: species.IsLegendary,
-	}, nil
+	if res.StatusCode != http.StatusOK {
+		switch res.StatusCode {
+		case http.StatusNotFound:
+			return PokemonResponse{}, service.ErrNotFound
+		default:
+			return PokemonResponse{}, service.ErrServiceUnavailable
+		}
+	}
+
+	var pokemon PokemonResponse
+	if err := json.NewDecoder(res.Body).Decode(&pokemon); err != nil {
+		return PokemonResponse{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+
+	return pokemon, nil
+}
+
+func mergeBattleInfo(p *model.Pokemon, res PokemonResponse, include service.Include) {
+	if include.Types {
+		for _, t := range res.Types {
+			p.Types = append(p.Types, t.Type.Name)
+		}
+	}
+	if include.Stats {
+		for _, s := range res.Stats {
+			p.Stats = append(p.Stats, model.Stat{Name: s.Stat.Name, BaseStat: s.BaseStat})
+		}
+		p.Height = res.Height
+		p.Weight = res.Weight
+	}
+	if include.Abilities {
+		for _, a := range res.Abilities {
+			p.Abilities = append(p.Abilities, model.Ability{Name: a.Ability.Name, IsHidden: a.IsHidden})
+		}
+	}
 }
 
 func tryToFindEnglishDescription(entries []FlavorTextEntry) string {
@@ -91,8 +268,23 @@ func tryToFindEnglishDescription(entries []FlavorTextEntry) string {
 	return ""
 }
 
+// descriptionsByLanguage keeps the first description of each language, like tryToFindEnglishDescription does for english.
+func descriptionsByLanguage(entries []FlavorTextEntry) map[string]string {
+	var descriptions map[string]string
+	for _, e := range entries {
+		lang := strings.ToLower(e.Language.Name)
+		if descriptions == nil {
+			descriptions = make(map[string]string)
+		}
+		if _, ok := descriptions[lang]; !ok {
+			descriptions[lang] = e.FlavorText
+		}
+	}
+	return descriptions
+}
+
 func (c *PokemonClient) getBasicInfo(ctx context.Context, name string) (*http.Response, error) {
-	getPokemonURL := fmt.Sprintf("%s/api/v2/pokemon-species/%s", c.pokeAPIURL, name)
+	getPokemonURL := fmt.Sprintf("%s/api/v2/pokemon-species/%s", c.URL(), url.PathEscape(name))
 	req, err := http.NewRequestWithContext(ctx, "GET", getPokemonURL, nil)
 	if err != nil {
 		return nil, err
@@ -100,3 +292,38 @@ func (c *PokemonClient) getBasicInfo(ctx context.Context, name string) (*http.Re
 
 	return c.client.Do(req)
 }
+
+func (c *PokemonClient) PokemonList(ctx context.Context, limit, offset int) (model.NamePage, error) {
+	return c.namedResourcePage(ctx, "pokemon-species", limit, offset)
+}
+
+// namedResourcePage lists a page of the names of an endpoint such as "pokemon-species".
+func (c *PokemonClient) namedResourcePage(ctx context.Context, endpoint string, limit, offset int) (model.NamePage, error) {
+	listURL := fmt.Sprintf("%s/api/v2/%s?limit=%d&offset=%d", c.URL(), endpoint, limit, offset)
+	req, err := http.NewRequestWithContext(ctx, "GET", listURL, nil)
+	if err != nil {
+		return model.NamePage{}, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return model.NamePage{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return model.NamePage{}, service.ErrServiceUnavailable
+	}
+
+	var list NamedAPIResourceList
+	if err := json.NewDecoder(res.Body).Decode(&list); err != nil {
+		return model.NamePage{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+
+	return model.NamePage{
+		Names:  resourceNames(list.Results),
+		Offset: offset,
+		Limit:  limit,
+		Total:  list.Count,
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/fprojetto/pokedex-api/internal/api-client/pokeapi"
@@ -44,8 +45,57 @@ func TestPokemonInfo(t *testing.T) {
 				},
 			},
 			expectedResult: model.Pokemon{
+				ID:          25,
 				Name:        "pikachu",
 				Description: "When several of these POKéMON gather, their electricity could build and cause lightning storms.",
+				Descriptions: map[string]string{
+					"en": "When several of these POKéMON gather, their electricity could build and cause lightning storms.",
+				},
+				Habitat:     "forest",
+				IsLegendary: client.BoolPtr(false),
+			},
+			expectedError: nil,
+		},
+		{
+			name:        "descriptions in several languages",
+			pokemonName: "pikachu",
+			mockStatus:  http.StatusOK,
+			mockResponse: pokeapi.PokemonSpeciesResponse{
+				ID:          25,
+				Name:        "pikachu",
+				Habitat:     "forest",
+				IsLegendary: client.BoolPtr(false),
+				FlavorTextEntries: []pokeapi.FlavorTextEntry{
+					flavorText("fr", "Il stocke l'électricité."),
+					flavorText("en", "It stores electricity."),
+					flavorText("fr", "Il accumule l'électricité."),
+				},
+			},
+			expectedResult: model.Pokemon{
+				ID:          25,
+				Name:        "pikachu",
+				Description: "It stores electricity.",
+				Descriptions: map[string]string{
+					"en": "It stores electricity.",
+					"fr": "Il stocke l'électricité.",
+				},
+				Habitat:     "forest",
+				IsLegendary: client.BoolPtr(false),
+			},
+		},
+		{
+			name:        "retrieval by national pokedex id",
+			pokemonName: "25",
+			mockStatus:  http.StatusOK,
+			mockResponse: pokeapi.PokemonSpeciesResponse{
+				ID:          25,
+				Name:        "pikachu",
+				Habitat:     "forest",
+				IsLegendary: client.BoolPtr(false),
+			},
+			expectedResult: model.Pokemon{
+				ID:          25,
+				Name:        "pikachu",
 				Habitat:     "forest",
 				IsLegendary: client.BoolPtr(false),
 			},
@@ -123,20 +173,221 @@ func TestPokemonInfo(t *testing.T) {
 			}))
 			defer ts.Close()
 
-			client, err := pokeapi.NewClient(ts.URL)
+			client, err := pokeapi.NewClient(ts.URL, client.DefaultConfig())
 			if err != nil {
 				require.NoError(t, err, "Failed to create pokeapi client")
 			}
-			result, err := client.PokemonInfo(context.Background(), tt.pokemonName)
+			result, err := client.PokemonInfo(context.Background(), tt.pokemonName, service.PokemonOptions{})
 
 			if tt.expectedError != nil {
 				assert.Equal(t, tt.expectedError, err)
 			} else {
+				assert.Equal(t, tt.expectedResult.ID, result.ID)
 				assert.Equal(t, tt.expectedResult.Name, result.Name)
 				assert.Equal(t, tt.expectedResult.Description, result.Description)
+				assert.Equal(t, tt.expectedResult.Descriptions, result.Descriptions)
 				assert.Equal(t, tt.expectedResult.Habitat, result.Habitat)
 				assert.Equal(t, tt.expectedResult.IsLegendary, result.IsLegendary)
 			}
 		})
 	}
 }
+
+func flavorText(lang, text string) pokeapi.FlavorTextEntry {
+	entry := pokeapi.FlavorTextEntry{FlavorText: text}
+	entry.Language.Name = lang
+	return entry
+}
+
+func TestPokemonInfoEscapesName(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pokemon-species/mew%2Ftwo%3F", r.URL.EscapedPath())
+		assert.Empty(t, r.URL.RawQuery)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client, err := pokeapi.NewClient(ts.URL, client.DefaultConfig())
+	require.NoError(t, err, "Failed to create pokeapi client")
+
+	_, err = client.PokemonInfo(context.Background(), "mew/two?", service.PokemonOptions{})
+	assert.Equal(t, service.ErrNotFound, err)
+}
+
+func TestPokemonList(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockResponse   any
+		mockStatus     int
+		expectedResult model.NamePage
+		expectedError  error
+	}{
+		{
+			name:       "successful listing",
+			mockStatus: http.StatusOK,
+			mockResponse: pokeapi.NamedAPIResourceList{
+				Count: 1025,
+				Results: []pokeapi.NamedAPIResource{
+					{Name: "pikachu", URL: "https://pokeapi.co/api/v2/pokemon-species/25/"},
+					{Name: "raichu", URL: "https://pokeapi.co/api/v2/pokemon-species/26/"},
+				},
+			},
+			expectedResult: model.NamePage{
+				Names:  []string{"pikachu", "raichu"},
+				Offset: 24,
+				Limit:  2,
+				Total:  1025,
+			},
+		},
+		{
+			name:       "api error",
+			mockStatus: http.StatusInternalServerError,
+			mockResponse: map[string]string{
+				"error": "internal server error",
+			},
+			expectedError: service.ErrServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/pokemon-species", r.URL.Path)
+				assert.Equal(t, "2", r.URL.Query().Get("limit"))
+				assert.Equal(t, "24", r.URL.Query().Get("offset"))
+				w.WriteHeader(tt.mockStatus)
+				json.NewEncoder(w).Encode(tt.mockResponse)
+			}))
+			defer ts.Close()
+
+			client, err := pokeapi.NewClient(ts.URL, client.DefaultConfig())
+			require.NoError(t, err, "Failed to create pokeapi client")
+
+			result, err := client.PokemonList(context.Background(), 2, 24)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestPokemonInfoInclude(t *testing.T) {
+	species := pokeapi.PokemonSpeciesResponse{
+		ID:          25,
+		Name:        "pikachu",
+		Habitat:     "forest",
+		IsLegendary: client.BoolPtr(false),
+	}
+	pokemon := pokeapi.PokemonResponse{
+		ID:     25,
+		Name:   "pikachu",
+		Height: 4,
+		Weight: 60,
+		Types: []pokeapi.PokemonType{
+			{Slot: 1, Type: pokeapi.NamedAPIResource{Name: "electric"}},
+		},
+		Stats: []pokeapi.PokemonStat{
+			{BaseStat: 35, Stat: pokeapi.NamedAPIResource{Name: "hp"}},
+			{BaseStat: 90, Stat: pokeapi.NamedAPIResource{Name: "speed"}},
+		},
+		Abilities: []pokeapi.PokemonAbility{
+			{Slot: 1, Ability: pokeapi.NamedAPIResource{Name: "static"}},
+			{Slot: 3, IsHidden: true, Ability: pokeapi.NamedAPIResource{Name: "lightning-rod"}},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		include          service.Include
+		pokemonStatus    int
+		expectedResult   model.Pokemon
+		expectedRequests []string
+	}{
+		{
+			name:             "no include skips the pokemon endpoint",
+			include:          service.Include{},
+			pokemonStatus:    http.StatusOK,
+			expectedResult:   model.Pokemon{ID: 25, Name: "pikachu", Habitat: "forest", IsLegendary: client.BoolPtr(false)},
+			expectedRequests: []string{"/api/v2/pokemon-species/pikachu"},
+		},
+		{
+			name:          "all sections merged",
+			include:       service.Include{Stats: true, Types: true, Abilities: true},
+			pokemonStatus: http.StatusOK,
+			expectedResult: model.Pokemon{
+				ID:          25,
+				Name:        "pikachu",
+				Habitat:     "forest",
+				IsLegendary: client.BoolPtr(false),
+				Types:       []string{"electric"},
+				Stats:       []model.Stat{{Name: "hp", BaseStat: 35}, {Name: "speed", BaseStat: 90}},
+				Abilities:   []model.Ability{{Name: "static"}, {Name: "lightning-rod", IsHidden: true}},
+				Height:      4,
+				Weight:      60,
+			},
+			expectedRequests: []string{"/api/v2/pokemon-species/pikachu", "/api/v2/pokemon/pikachu"},
+		},
+		{
+			name:          "only selected sections merged",
+			include:       service.Include{Types: true},
+			pokemonStatus: http.StatusOK,
+			expectedResult: model.Pokemon{
+				ID:          25,
+				Name:        "pikachu",
+				Habitat:     "forest",
+				IsLegendary: client.BoolPtr(false),
+				Types:       []string{"electric"},
+			},
+			expectedRequests: []string{"/api/v2/pokemon-species/pikachu", "/api/v2/pokemon/pikachu"},
+		},
+		{
+			name:          "pokemon endpoint failure is partial",
+			include:       service.Include{Stats: true, Abilities: true},
+			pokemonStatus: http.StatusInternalServerError,
+			expectedResult: model.Pokemon{
+				ID:          25,
+				Name:        "pikachu",
+				Habitat:     "forest",
+				IsLegendary: client.BoolPtr(false),
+				Unavailable: []string{service.IncludeStats, service.IncludeAbilities},
+			},
+			expectedRequests: []string{"/api/v2/pokemon-species/pikachu", "/api/v2/pokemon/pikachu"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				requests = append(requests, r.URL.Path)
+				mu.Unlock()
+
+				switch r.URL.Path {
+				case "/api/v2/pokemon-species/pikachu":
+					json.NewEncoder(w).Encode(species)
+				case "/api/v2/pokemon/pikachu":
+					w.WriteHeader(tt.pokemonStatus)
+					json.NewEncoder(w).Encode(pokemon)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer ts.Close()
+
+			client, err := pokeapi.NewClient(ts.URL, client.DefaultConfig())
+			require.NoError(t, err, "Failed to create pokeapi client")
+
+			result, err := client.PokemonInfo(context.Background(), "pikachu", service.PokemonOptions{Include: tt.include})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+			assert.ElementsMatch(t, tt.expectedRequests, requests)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+// maxSpriteSize bounds the size of the images downloaded from the sprite hosts.
+const maxSpriteSize = 5 << 20
+
+// SpriteURL resolves the URL of the requested sprite variant from the pokemon resource.
+func (c *PokemonClient) SpriteURL(ctx context.Context, name string, variant string) (string, error) {
+	pokemon, err := c.battleInfo(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	var spriteURL *string
+	switch variant {
+	case service.SpriteFrontDefault:
+		spriteURL = pokemon.Sprites.FrontDefault
+	case service.SpriteShiny:
+		spriteURL = pokemon.Sprites.FrontShiny
+	case service.SpriteOfficialArtwork:
+		spriteURL = pokemon.Sprites.Other.OfficialArtwork.FrontDefault
+	default:
+		return "", service.ErrInvalidSpriteVariant
+	}
+
+	if spriteURL == nil || *spriteURL == "" {
+		return "", service.ErrSpriteNotFound
+	}
+
+	return *spriteURL, nil
+}
+
+// Sprite downloads the image at spriteURL.
+func (c *PokemonClient) Sprite(ctx context.Context, spriteURL string) (model.Sprite, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", spriteURL, nil)
+	if err != nil {
+		return model.Sprite{}, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return model.Sprite{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		switch res.StatusCode {
+		case http.StatusNotFound:
+			return model.Sprite{}, service.ErrSpriteNotFound
+		default:
+			return model.Sprite{}, service.ErrServiceUnavailable
+		}
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || !strings.HasPrefix(mediaType, "image/") {
+		return model.Sprite{}, service.ErrServiceUnavailable
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxSpriteSize+1))
+	if err != nil {
+		return model.Sprite{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+	if len(data) > maxSpriteSize {
+		return model.Sprite{}, service.ErrServiceUnavailable
+	}
+
+	modTime := time.Now().UTC()
+	if lastModified, err := http.ParseTime(res.Header.Get("Last-Modified")); err == nil {
+		modTime = lastModified
+	}
+
+	return model.Sprite{
+		ContentType: contentType,
+		ModTime:     modTime,
+		Data:        data,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package pokeapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api-client/pokeapi"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpriteURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		variant       string
+		expectedURL   string
+		expectedError error
+	}{
+		{name: "front default", variant: service.SpriteFrontDefault, expectedURL: "https://sprites.example/25.png"},
+		{name: "official artwork", variant: service.SpriteOfficialArtwork, expectedURL: "https://sprites.example/artwork/25.png"},
+		{name: "missing shiny", variant: service.SpriteShiny, expectedError: service.ErrSpriteNotFound},
+		{name: "unknown variant", variant: "back_female", expectedError: service.ErrInvalidSpriteVariant},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pokemon/pikachu", r.URL.Path)
+		w.Write([]byte(`{
+			"id": 25,
+			"name": "pikachu",
+			"sprites": {
+				"front_default": "https://sprites.example/25.png",
+				"front_shiny": null,
+				"other": {"official-artwork": {"front_default": "https://sprites.example/artwork/25.png"}}
+			}
+		}`))
+	}))
+	defer ts.Close()
+
+	client, err := pokeapi.NewClient(ts.URL, client.DefaultConfig())
+	require.NoError(t, err, "Failed to create pokeapi client")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spriteURL, err := client.SpriteURL(context.Background(), "pikachu", tt.variant)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedURL, spriteURL)
+			}
+		})
+	}
+}
+
+func TestSprite(t *testing.T) {
+	tests := []struct {
+		name          string
+		contentType   string
+		status        int
+		expectedError error
+	}{
+		{name: "png image", contentType: "image/png", status: http.StatusOK},
+		{name: "not an image", contentType: "text/html; charset=utf-8", status: http.StatusOK, expectedError: service.ErrServiceUnavailable},
+		{name: "image not found", contentType: "image/png", status: http.StatusNotFound, expectedError: service.ErrSpriteNotFound},
+		{name: "host throttling", contentType: "image/png", status: http.StatusTooManyRequests, expectedError: service.ErrServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+				w.WriteHeader(tt.status)
+				w.Write([]byte("\x89PNG"))
+			}))
+			defer ts.Close()
+
+			client, err := pokeapi.NewClient(ts.URL, client.DefaultConfig())
+			require.NoError(t, err, "Failed to create pokeapi client")
+
+			sprite, err := client.Sprite(context.Background(), ts.URL+"/25.png")
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "image/png", sprite.ContentType)
+				assert.Equal(t, []byte("\x89PNG"), sprite.Data)
+				assert.Equal(t, 2015, sprite.ModTime.Year())
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type TypeResponse struct {
+	ID              int             `json:"id"`
+	Name            string          `json:"name"`
+	DamageRelations DamageRelations `json:"damage_relations"`
+}
+
+type DamageRelations struct {
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+}
+
+func (c *PokemonClient) TypeRelations(ctx context.Context, name string) (model.TypeRelations, error) {
+	typeURL := fmt.Sprintf("%s/api/v2/type/%s", c.URL(), url.PathEscape(name))
+	req, err := http.NewRequestWithContext(ctx, "GET", typeURL, nil)
+	if err != nil {
+		return model.TypeRelations{}, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return model.TypeRelations{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		switch res.StatusCode {
+		case http.StatusNotFound:
+			return model.TypeRelations{}, service.ErrTypeNotFound
+		default:
+			return model.TypeRelations{}, service.ErrServiceUnavailable
+		}
+	}
+
+	var t TypeResponse
+	if err := json.NewDecoder(res.Body).Decode(&t); err != nil {
+		return model.TypeRelations{}, errors.Join(err, service.ErrServiceUnavailable)
+	}
+
+	dr := t.DamageRelations
+	return model.TypeRelations{
+		Name:             t.Name,
+		DoubleDamageTo:   resourceNames(dr.DoubleDamageTo),
+		HalfDamageTo:     resourceNames(dr.HalfDamageTo),
+		NoDamageTo:       resourceNames(dr.NoDamageTo),
+		DoubleDamageFrom: resourceNames(dr.DoubleDamageFrom),
+		HalfDamageFrom:   resourceNames(dr.HalfDamageFrom),
+		NoDamageFrom:     resourceNames(dr.NoDamageFrom),
+	}, nil
+}
+
+func resourceNames(resources []NamedAPIResource) []string {
+	names := make([]string, 0, len(resources))
+	for _, r := range resources {
+		names = append(names, r.Name)
+	}
+	return names
+}
@@ -0,0 +1,86 @@
+package pokeapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api-client/pokeapi"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeRelations(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockStatus     int
+		mockBody       string
+		expectedResult model.TypeRelations
+		expectedError  error
+	}{
+		{
+			name:       "successful retrieval",
+			mockStatus: http.StatusOK,
+			mockBody: `{
+				"id": 5,
+				"name": "ground",
+				"damage_relations": {
+					"double_damage_to": [{"name": "fire"}, {"name": "electric"}],
+					"half_damage_to": [{"name": "grass"}],
+					"no_damage_to": [{"name": "flying"}],
+					"double_damage_from": [{"name": "water"}],
+					"half_damage_from": [{"name": "rock"}],
+					"no_damage_from": [{"name": "electric"}]
+				}
+			}`,
+			expectedResult: model.TypeRelations{
+				Name:             "ground",
+				DoubleDamageTo:   []string{"fire", "electric"},
+				HalfDamageTo:     []string{"grass"},
+				NoDamageTo:       []string{"flying"},
+				DoubleDamageFrom: []string{"water"},
+				HalfDamageFrom:   []string{"rock"},
+				NoDamageFrom:     []string{"electric"},
+			},
+		},
+		{
+			name:          "type not found",
+			mockStatus:    http.StatusNotFound,
+			mockBody:      `{}`,
+			expectedError: service.ErrTypeNotFound,
+		},
+		{
+			name:          "api error",
+			mockStatus:    http.StatusInternalServerError,
+			mockBody:      `{}`,
+			expectedError: service.ErrServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/type/ground", r.URL.Path)
+				w.WriteHeader(tt.mockStatus)
+				w.Write([]byte(tt.mockBody))
+			}))
+			defer ts.Close()
+
+			client, err := pokeapi.NewClient(ts.URL, client.DefaultConfig())
+			require.NoError(t, err, "Failed to create pokeapi client")
+
+			result, err := client.TypeRelations(context.Background(), "ground")
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/fprojetto/pokedex-api/internal/service"
 	"github.com/fprojetto/pokedex-api/pkg/client"
@@ -35,19 +36,34 @@ type TranslationRequest struct {
 }
 
 type TranslationClient struct {
-	translationAPIURL string
+	// translationAPIURL can be changed while requests are made, see SetURL.
+	translationAPIURL atomic.Pointer[string]
 	client            *http.Client
 }
 
-func NewClient(translationAPIURL string) (*TranslationClient, error) {
+func NewClient(translationAPIURL string, cfg client.Config) (*TranslationClient, error) {
+	c := &TranslationClient{client: client.HttpClient(cfg)}
+	if err := c.SetURL(translationAPIURL); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CheckURL returns the error SetURL would return for translationAPIURL.
+func CheckURL(translationAPIURL string) error {
 	if translationAPIURL == "" {
-		return nil, errors.New("translationAPIURL empty string")
+		return errors.New("translationAPIURL empty string")
 	}
+	return nil
+}
 
-	return &TranslationClient{
-		translationAPIURL: translationAPIURL,
-		client:            client.HttpClient(),
-	}, nil
+// SetURL changes the base url of the translation API for the requests made from now on.
+func (c *TranslationClient) SetURL(translationAPIURL string) error {
+	if err := CheckURL(translationAPIURL); err != nil {
+		return err
+	}
+	c.translationAPIURL.Store(&translationAPIURL)
+	return nil
 }
 
 func (c *TranslationClient) Translate(ctx context.Context, style service.TranslationStyle, text string) (string, error) {
@@ -61,7 +77,7 @@ func (c *TranslationClient) Translate(ctx context.Context, style service.Transla
 		return "", errors.New("unsupported translation style")
 	}
 
-	getTranslationURL := fmt.Sprintf("%s/translate/%s", c.translationAPIURL, translatorServiceName)
+	getTranslationURL := fmt.Sprintf("%s/translate/%s", *c.translationAPIURL.Load(), translatorServiceName)
 	translationRequest := TranslationRequest{Text: text}
 	jsonBody, err := json.Marshal(translationRequest)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/fprojetto/pokedex-api/internal/api-client/translationapi"
 	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -132,7 +133,7 @@ func TestTranslationClient_Translate(t *testing.T) {
 			}))
 			defer ts.Close()
 
-			client, err := translationapi.NewClient(ts.URL)
+			client, err := translationapi.NewClient(ts.URL, client.DefaultConfig())
 			require.NoError(t, err, "Failed to create translationapi client")
 
 			result, err := client.Translate(context.Background(), tt.style, tt.text)
@@ -158,3 +159,30 @@ func TestTranslationClient_Translate(t *testing.T) {
 func BoolPtr(b bool) *bool {
 	return &b
 }
+
+func TestTranslationClientSetURL(t *testing.T) {
+	newServer := func(translated string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(translationapi.TranslationResponse{
+				Success:  translationapi.SuccessInfo{Total: 1},
+				Contents: translationapi.TranslationContent{Translated: translated},
+			})
+		}))
+	}
+	before, after := newServer("before"), newServer("after")
+	defer before.Close()
+	defer after.Close()
+
+	c, err := translationapi.NewClient(before.URL, client.DefaultConfig())
+	require.NoError(t, err)
+	result, err := c.Translate(context.Background(), service.Yoda, "text")
+	require.NoError(t, err)
+	assert.Equal(t, "before", result)
+
+	require.NoError(t, c.SetURL(after.URL))
+	result, err = c.Translate(context.Background(), service.Yoda, "text")
+	require.NoError(t, err)
+	assert.Equal(t, "after", result)
+
+	assert.Error(t, c.SetURL(""))
+}
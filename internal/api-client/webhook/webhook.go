@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+)
+
+// Headers of the deliveries.
+const (
+	HeaderEvent     = "X-Pokedex-Event"
+	HeaderDelivery  = "X-Pokedex-Delivery"
+	HeaderTimestamp = "X-Pokedex-Timestamp"
+	HeaderSignature = "X-Pokedex-Signature"
+)
+
+// Payload is the body of a delivery.
+type Payload struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"createdAt"`
+	Data      any       `json:"data"`
+}
+
+type WebhookClient struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewClient returns a client delivering the webhooks to the addresses allowed by allowAddr, usually
+// service.IsPublicAddr, or to any address when it is nil. The addresses are checked when connecting, after
+// the host is resolved, and redirects are not followed, so that a webhook cannot reach another address.
+func NewClient(cfg client.Config, allowAddr func(netip.Addr) bool) *WebhookClient {
+	if allowAddr != nil {
+		cfg.DialControl = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !allowAddr(addrPort.Addr()) {
+				return fmt.Errorf("webhook address %s is not allowed", addrPort.Addr())
+			}
+			return nil
+		}
+	}
+
+	c := client.HttpClient(cfg)
+	c.CheckRedirect = func(*http.Request, []*http.Request) error {
+		// the redirect is a response but 2xx, hence a failure
+		return http.ErrUseLastResponse
+	}
+	return &WebhookClient{client: c, now: time.Now}
+}
+
+// Sign returns the signature of a delivery: the hex encoded HMAC-SHA256, keyed with the secret of the
+// subscription, of the timestamp (in unix seconds), a dot and the body. Receivers should compare it in
+// constant time, and reject old timestamps to prevent replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliver posts the event of d to the url of its subscription. Any response but 2xx is a failure.
+func (c *WebhookClient) Deliver(ctx context.Context, d service.WebhookDelivery) error {
+	body, err := json.Marshal(Payload{
+		ID:        d.Event.ID,
+		Type:      d.Event.Type,
+		CreatedAt: d.Event.CreatedAt,
+		Data:      d.Event.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", d.Subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := c.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pokedex-api-webhooks")
+	req.Header.Set(HeaderEvent, d.Event.Type)
+	req.Header.Set(HeaderDelivery, d.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(d.Subscription.Secret, timestamp, body))
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	// drained so that the connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api-client/webhook"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver is a webhook endpoint verifying the signatures, failing the first failures deliveries.
+type receiver struct {
+	t        *testing.T
+	secret   string
+	failures int32
+
+	calls    atomic.Int32
+	payloads chan webhook.Payload
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.calls.Add(1) <= r.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(r.t, err)
+
+	timestamp, err := strconv.ParseInt(req.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(r.t, err)
+	expected := webhook.Sign(r.secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(req.Header.Get(webhook.HeaderSignature))) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var payload webhook.Payload
+	require.NoError(r.t, json.Unmarshal(body, &payload))
+	assert.Equal(r.t, payload.Type, req.Header.Get(webhook.HeaderEvent))
+	assert.NotEmpty(r.t, req.Header.Get(webhook.HeaderDelivery))
+	assert.Equal(r.t, "application/json", req.Header.Get("Content-Type"))
+
+	r.payloads <- payload
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		webhook.Sign("secret", 1700000000, []byte("{}")),
+	)
+}
+
+func TestWebhookClient_Deliver(t *testing.T) {
+	r := &receiver{t: t, secret: "s3cret", payloads: make(chan webhook.Payload, 1)}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	c := webhook.NewClient(client.DefaultConfig(), nil)
+	createdAt := time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)
+	delivery := service.WebhookDelivery{
+		ID:           "delivery1",
+		Subscription: service.WebhookSubscription{URL: srv.URL, Secret: "s3cret"},
+		Event:        service.WebhookEvent{ID: "event1", Type: service.EventJobCompleted, CreatedAt: createdAt, Data: map[string]string{"id": "job1"}},
+	}
+
+	require.NoError(t, c.Deliver(context.Background(), delivery))
+	payload := <-r.payloads
+	assert.Equal(t, webhook.Payload{
+		ID:        "event1",
+		Type:      service.EventJobCompleted,
+		CreatedAt: createdAt,
+		Data:      map[string]any{"id": "job1"},
+	}, payload)
+
+	t.Run("address not allowed", func(t *testing.T) {
+		c := webhook.NewClient(client.DefaultConfig(), service.IsPublicAddr)
+		assert.ErrorContains(t, c.Deliver(context.Background(), delivery), "webhook address 127.0.0.1 is not allowed")
+	})
+	t.Run("redirect not followed", func(t *testing.T) {
+		redirect := httptest.NewServer(http.RedirectHandler(srv.URL, http.StatusFound))
+		defer redirect.Close()
+
+		delivery := delivery
+		delivery.Subscription.URL = redirect.URL
+		assert.EqualError(t, c.Deliver(context.Background(), delivery), "unexpected response status 302")
+	})
+	t.Run("wrong secret", func(t *testing.T) {
+		delivery.Subscription.Secret = "other"
+		assert.EqualError(t, c.Deliver(context.Background(), delivery), "unexpected response status 401")
+	})
+}
+
+func TestWebhooksDeliveredWithRetries(t *testing.T) {
+	r := &receiver{t: t, secret: "s3cret", failures: 2, payloads: make(chan webhook.Payload, 1)}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	webhooks := service.NewWebhooks(
+		webhook.NewClient(client.DefaultConfig(), nil).Deliver,
+		nil,
+		service.WebhookRetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond},
+		service.WebhookLimits{MaxSubscriptions: 10, Workers: 1, MaxQueued: 10, MaxDeadLetters: 10},
+		time.Now,
+	)
+	_, err := webhooks.Subscribe(context.Background(), srv.URL, []string{service.EventJobCompleted}, "s3cret")
+	require.NoError(t, err)
+
+	webhooks.Publish(service.EventJobCompleted, map[string]string{"id": "job1"})
+	require.NoError(t, webhooks.Shutdown(context.Background()))
+
+	assert.Equal(t, int32(3), r.calls.Load())
+	assert.Equal(t, map[string]any{"id": "job1"}, (<-r.payloads).Data)
+	assert.Empty(t, webhooks.DeadLetters(context.Background()))
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/fprojetto/pokedex-api/pkg/auth"
+)
+
+// RequireToken serves next only to the requests with the admin bearer token, see auth.RequireToken.
+func RequireToken(token string, next http.Handler) http.Handler {
+	return auth.RequireToken(token, "admin", func(w http.ResponseWriter, r *http.Request, status int, message string) {
+		code := ErrCodeUnauthorized
+		if status == http.StatusForbidden {
+			code = ErrCodeForbidden
+		}
+		WriteError(w, r, status, code, message)
+	}, next)
+}
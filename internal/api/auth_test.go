@@ -0,0 +1,45 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		token          string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "valid token", token: "s3cret", authorization: "Bearer s3cret", expectedStatus: http.StatusNoContent},
+		{name: "invalid token", token: "s3cret", authorization: "Bearer guess", expectedStatus: http.StatusUnauthorized},
+		{name: "missing token", token: "s3cret", expectedStatus: http.StatusUnauthorized},
+		{name: "not a bearer token", token: "s3cret", authorization: "Basic s3cret", expectedStatus: http.StatusUnauthorized},
+		{name: "no token configured", authorization: "Bearer ", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/admin/config/reload", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			res := httptest.NewRecorder()
+			api.RequireToken(tt.token, ok).ServeHTTP(res, req)
+
+			assert.Equal(t, tt.expectedStatus, res.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, res.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
@@ -0,0 +1,440 @@
+package api
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a response encoding selectable with the Accept header or the ?format= query parameter.
+type Format struct {
+	// Name is the value of ?format= selecting the format.
+	Name string
+	// MediaTypes are the media types served by the format, the first one is used as Content-Type.
+	MediaTypes []string
+	// Envelope tells whether Encode keeps the whole Envelope. When it does not, the metadata is sent as headers.
+	Envelope bool
+	Encode   func(w io.Writer, resp Envelope) error
+}
+
+var (
+	formatsMu sync.RWMutex
+	// formats is the registry of the supported formats, the first one is the default.
+	formats = []Format{
+		{Name: "json", MediaTypes: []string{"application/json"}, Envelope: true, Encode: encodeJSON},
+		{Name: "yaml", MediaTypes: []string{"application/yaml", "application/x-yaml", "text/yaml"}, Envelope: true, Encode: encodeYAML},
+		{Name: "xml", MediaTypes: []string{"application/xml", "text/xml"}, Envelope: true, Encode: encodeXML},
+		{Name: "csv", MediaTypes: []string{"text/csv"}, Encode: encodeCSV},
+	}
+)
+
+func defaultFormat() Format {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	return formats[0]
+}
+
+// RegisterFormat adds a format to the registry, replacing the one with the same name if any.
+func RegisterFormat(f Format) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	if i := slices.IndexFunc(formats, func(g Format) bool { return g.Name == f.Name }); i >= 0 {
+		formats[i] = f
+		return
+	}
+	formats = append(formats, f)
+}
+
+// SupportedMediaTypes lists the canonical media type of every registered format.
+func SupportedMediaTypes() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	types := make([]string, 0, len(formats))
+	for _, f := range formats {
+		types = append(types, f.MediaTypes[0])
+	}
+	return types
+}
+
+// NegotiateFormat picks the format of the response to r: ?format= wins over the Accept header. The quality of
+// each media type is set by the most specific media range matching it (RFC 9110 section 12.5.1), and the format with
+// the highest quality is picked, the first registered on a tie. A client preferring text/html, i.e. a browser,
+// gets the default format when it accepts it. Without both the default format is used.
+func NegotiateFormat(r *http.Request) (Format, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	if name := r.URL.Query().Get("format"); name != "" {
+		i := slices.IndexFunc(formats, func(f Format) bool { return strings.EqualFold(f.Name, name) })
+		if i < 0 {
+			return Format{}, false
+		}
+		return formats[i], true
+	}
+
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return formats[0], true
+	}
+
+	ranges := parseAccept(accept)
+	if prefersHTML(ranges) && formatQuality(ranges, formats[0]) > 0 {
+		return formats[0], true
+	}
+
+	best, bestQ := -1, 0.0
+	for i, f := range formats {
+		if q := formatQuality(ranges, f); q > bestQ {
+			best, bestQ = i, q
+		}
+	}
+	if best < 0 {
+		return Format{}, false
+	}
+	return formats[best], true
+}
+
+type formatKey struct{}
+
+// WithFormat negotiates the format of the response before serving h, so that a client accepting none of the
+// supported formats gets a NOT_ACCEPTABLE error before any work is done. The format is kept in the request
+// context for WriteData and WriteError.
+func WithFormat(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format, ok := NegotiateFormat(r)
+		if !ok {
+			writeNotAcceptable(w, r)
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), formatKey{}, format)))
+	})
+}
+
+// requestFormat returns the format negotiated by WithFormat, or negotiates it for the handlers served without.
+func requestFormat(r *http.Request) (Format, bool) {
+	if format, ok := r.Context().Value(formatKey{}).(Format); ok {
+		return format, true
+	}
+	return NegotiateFormat(r)
+}
+
+type weightedRange struct {
+	mediaRange string
+	q          float64
+}
+
+// parseAccept returns the media ranges of an Accept header with their quality, q=0 meaning not acceptable.
+func parseAccept(accept string) []weightedRange {
+	var ranges []weightedRange
+	for _, part := range strings.Split(accept, ",") {
+		r, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			// mime rejects the bare "*" sent by some old clients
+			if strings.TrimSpace(part) != "*" {
+				continue
+			}
+			r = "*/*"
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, weightedRange{mediaRange: r, q: q})
+	}
+	return ranges
+}
+
+// formatQuality returns the highest quality among the media types of f, 0 when none is acceptable.
+func formatQuality(ranges []weightedRange, f Format) float64 {
+	var best float64
+	for _, t := range f.MediaTypes {
+		best = max(best, mediaTypeQuality(ranges, t))
+	}
+	return best
+}
+
+// mediaTypeQuality returns the quality of the most specific range matching mediaType, 0 when none does.
+func mediaTypeQuality(ranges []weightedRange, mediaType string) float64 {
+	q, specificity := 0.0, 0
+	for _, r := range ranges {
+		if s := matchSpecificity(r.mediaRange, mediaType); s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
+
+// matchSpecificity tells how specifically mediaRange matches mediaType: 3 for the type itself, 2 for its
+// subtype wildcard, 1 for */* and 0 when it does not match.
+func matchSpecificity(mediaRange, mediaType string) int {
+	if mediaRange == mediaType {
+		return 3
+	}
+	if mediaRange == "*/*" {
+		return 1
+	}
+	if prefix, ok := strings.CutSuffix(mediaRange, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+		return 2
+	}
+	return 0
+}
+
+// prefersHTML tells whether text/html is among the media ranges of the highest quality, as sent by browsers.
+func prefersHTML(ranges []weightedRange) bool {
+	var top float64
+	for _, r := range ranges {
+		top = max(top, r.q)
+	}
+	return top > 0 && slices.ContainsFunc(ranges, func(r weightedRange) bool {
+		return r.mediaRange == "text/html" && r.q == top
+	})
+}
+
+func encodeJSON(w io.Writer, resp Envelope) error {
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// envelopeTree returns resp as a YAML node tree. Going through JSON keeps the json tags and field order
+// of the payloads, and YAML being a superset of JSON the tree can be read back as is.
+func envelopeTree(resp Envelope) (*yaml.Node, error) {
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Content[0], nil
+}
+
+func encodeYAML(w io.Writer, resp Envelope) error {
+	tree, err := envelopeTree(resp)
+	if err != nil {
+		return err
+	}
+	resetStyle(tree)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(tree); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// resetStyle drops the JSON flow style and quoting, so that the tree is written as block YAML.
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
+
+// encodeXML writes the envelope as a <response> element. Object fields become child elements,
+// array items become <item> elements and keys that are not valid element names become <entry key="...">.
+func encodeXML(w io.Writer, resp Envelope) error {
+	tree, err := envelopeTree(resp)
+	if err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if err := writeXMLNode(enc, xml.StartElement{Name: xml.Name{Local: "response"}}, tree); err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func writeXMLNode(enc *xml.Encoder, start xml.StartElement, n *yaml.Node) error {
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if err := writeXMLNode(enc, xmlElement(n.Content[i].Value), n.Content[i+1]); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, c := range n.Content {
+			if err := writeXMLNode(enc, xml.StartElement{Name: xml.Name{Local: "item"}}, c); err != nil {
+				return err
+			}
+		}
+	default:
+		if v := scalarValue(n); v != "" {
+			if err := enc.EncodeToken(xml.CharData(v)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+func xmlElement(key string) xml.StartElement {
+	valid := key != "" && !strings.HasPrefix(strings.ToLower(key), "xml")
+	for i, r := range key {
+		letter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !letter && (i == 0 || !(r == '-' || r == '.' || (r >= '0' && r <= '9'))) {
+			valid = false
+			break
+		}
+	}
+
+	if valid {
+		return xml.StartElement{Name: xml.Name{Local: key}}
+	}
+	return xml.StartElement{
+		Name: xml.Name{Local: "entry"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}},
+	}
+}
+
+func scalarValue(n *yaml.Node) string {
+	if n.Tag == "!!null" {
+		return ""
+	}
+	return n.Value
+}
+
+// encodeCSV writes data, or the error when there is none, as a table: one row per item of a list,
+// a single row otherwise. Nested objects are flattened into "parent.child" columns, nested lists
+// are joined with ";" and the fields of their items with ":".
+func encodeCSV(w io.Writer, resp Envelope) error {
+	var items []*yaml.Node
+	switch {
+	case resp.Data != nil:
+		tree, err := envelopeTree(Envelope{Data: resp.Data})
+		if err != nil {
+			return err
+		}
+		items = []*yaml.Node{tree.Content[1]}
+		if data := tree.Content[1]; data.Kind == yaml.SequenceNode {
+			items = data.Content
+		}
+	case resp.Error != nil:
+		tree, err := envelopeTree(Envelope{Error: resp.Error})
+		if err != nil {
+			return err
+		}
+		items = []*yaml.Node{tree}
+	}
+
+	var columns []string
+	rows := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		row := map[string]string{}
+		flatten(item, "", row, &columns)
+		rows = append(rows, row)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, 0, len(columns))
+		for _, c := range columns {
+			record = append(record, row[c])
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func flatten(n *yaml.Node, prefix string, row map[string]string, columns *[]string) {
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i].Value
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flatten(n.Content[i+1], key, row, columns)
+		}
+		return
+	}
+
+	column := cmp.Or(prefix, "value")
+	if _, ok := row[column]; !ok && !slices.Contains(*columns, column) {
+		*columns = append(*columns, column)
+	}
+	row[column] = joinValues(n, ";")
+}
+
+func joinValues(n *yaml.Node, sep string) string {
+	switch n.Kind {
+	case yaml.SequenceNode:
+		values := make([]string, 0, len(n.Content))
+		for _, c := range n.Content {
+			values = append(values, joinValues(c, ":"))
+		}
+		return strings.Join(values, sep)
+	case yaml.MappingNode:
+		values := make([]string, 0, len(n.Content)/2)
+		for i := 1; i < len(n.Content); i += 2 {
+			values = append(values, joinValues(n.Content[i], ":"))
+		}
+		return strings.Join(values, sep)
+	default:
+		return scalarValue(n)
+	}
+}
+
+// writeEnvelope encodes resp with format. Formats dropping the envelope get the metadata as headers.
+func writeEnvelope(w http.ResponseWriter, format Format, resp Envelope, status int) error {
+	var buf bytes.Buffer
+	if err := format.Encode(&buf, resp); err != nil {
+		return err
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", format.MediaTypes[0])
+	h.Add("Vary", "Accept")
+	if !format.Envelope && resp.Meta != nil && resp.Meta.Pagination != nil {
+		p := resp.Meta.Pagination
+		h.Set("X-Total-Count", strconv.Itoa(p.Total))
+		if p.NextCursor != "" {
+			h.Set("X-Next-Cursor", p.NextCursor)
+		}
+		if p.PrevCursor != "" {
+			h.Set("X-Prev-Cursor", p.PrevCursor)
+		}
+	}
+
+	w.WriteHeader(status)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
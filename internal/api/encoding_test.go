@@ -0,0 +1,203 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pokemon struct {
+	Name  string   `json:"name"`
+	Types []string `json:"types"`
+}
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		accept       string
+		expectedName string
+	}{
+		{name: "default", url: "/", expectedName: "json"},
+		{name: "format parameter", url: "/?format=CSV", accept: "application/json", expectedName: "csv"},
+		{name: "exact media type", url: "/", accept: "application/xml", expectedName: "xml"},
+		{name: "alias media type", url: "/", accept: "application/x-yaml", expectedName: "yaml"},
+		{name: "highest quality wins", url: "/", accept: "application/json;q=0.5, text/csv;q=0.9", expectedName: "csv"},
+		{name: "q=0 is refused", url: "/", accept: "text/csv;q=0, application/yaml;q=0.1", expectedName: "yaml"},
+		{name: "subtype wildcard", url: "/", accept: "text/*", expectedName: "yaml"},
+		{name: "browser", url: "/", accept: "text/html,application/xhtml+xml,*/*;q=0.8", expectedName: "json"},
+		{name: "browser preferring xml over the wildcard", url: "/", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", expectedName: "json"},
+		{name: "most specific range wins", url: "/", accept: "*/*;q=0.1, application/json;q=0", expectedName: "yaml"},
+		{name: "wildcard tie", url: "/", accept: "application/xml;q=0.5, */*;q=0.5", expectedName: "json"},
+		{name: "specific range under the wildcard", url: "/", accept: "application/xml;q=0.4, */*;q=0.5", expectedName: "json"},
+		{name: "everything refused", url: "/", accept: "*/*;q=0"},
+		{name: "unknown format parameter", url: "/?format=pdf"},
+		{name: "unsupported media type", url: "/", accept: "application/pdf, text/html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			req.Header.Set("Accept", tt.accept)
+
+			format, ok := api.NegotiateFormat(req)
+
+			assert.Equal(t, tt.expectedName != "", ok)
+			assert.Equal(t, tt.expectedName, format.Name)
+		})
+	}
+}
+
+func TestWithFormat(t *testing.T) {
+	t.Run("negotiated before serving", func(t *testing.T) {
+		h := api.WithFormat(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the format stays the one negotiated, even when the request changes afterwards
+			r.Header.Set("Accept", "application/json")
+			api.WriteData(w, r, pokemon{Name: "pikachu"}, http.StatusOK)
+		}))
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept", "text/csv")
+
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "text/csv", res.Header().Get("Content-Type"))
+	})
+
+	t.Run("not acceptable before serving", func(t *testing.T) {
+		served := false
+		h := api.WithFormat(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			served = true
+		}))
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept", "application/pdf")
+
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotAcceptable, res.Code)
+		assert.False(t, served)
+	})
+}
+
+func TestWriteDataPageFormats(t *testing.T) {
+	data := []pokemon{{Name: "mr-mime", Types: []string{"psychic", "fairy"}}, {Name: "pikachu", Types: []string{"electric"}}}
+	page := &api.Pagination{NextCursor: "next", Total: 10}
+
+	tests := []struct {
+		format              string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			format:              "yaml",
+			expectedContentType: "application/yaml",
+			expectedBody: `data:
+  - name: mr-mime
+    types:
+      - psychic
+      - fairy
+  - name: pikachu
+    types:
+      - electric
+meta:
+  request_id: unknown
+  pagination:
+    next_cursor: next
+    total: 10
+`,
+		},
+		{
+			format:              "xml",
+			expectedContentType: "application/xml",
+			expectedBody: `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <data>
+    <item>
+      <name>mr-mime</name>
+      <types>
+        <item>psychic</item>
+        <item>fairy</item>
+      </types>
+    </item>
+    <item>
+      <name>pikachu</name>
+      <types>
+        <item>electric</item>
+      </types>
+    </item>
+  </data>
+  <meta>
+    <request_id>unknown</request_id>
+    <pagination>
+      <next_cursor>next</next_cursor>
+      <total>10</total>
+    </pagination>
+  </meta>
+</response>
+`,
+		},
+		{
+			format:              "csv",
+			expectedContentType: "text/csv",
+			expectedBody:        "name,types\nmr-mime,psychic;fairy\npikachu,electric\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			api.WriteDataPage(res, httptest.NewRequest("GET", "/?format="+tt.format, nil), data, page, http.StatusOK)
+
+			assert.Equal(t, http.StatusOK, res.Code)
+			assert.Equal(t, tt.expectedContentType, res.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, res.Body.String())
+		})
+	}
+
+	t.Run("csv pagination headers", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		api.WriteDataPage(res, httptest.NewRequest("GET", "/?format=csv", nil), data, page, http.StatusOK)
+
+		assert.Equal(t, "10", res.Header().Get("X-Total-Count"))
+		assert.Equal(t, "next", res.Header().Get("X-Next-Cursor"))
+		assert.Empty(t, res.Header().Get("X-Prev-Cursor"))
+	})
+}
+
+func TestWriteNotAcceptable(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept", "application/pdf")
+
+	t.Run("data is refused", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		api.WriteData(res, req, pokemon{Name: "pikachu"}, http.StatusOK)
+
+		require.Equal(t, http.StatusNotAcceptable, res.Code)
+		assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+
+		var envelope struct {
+			Error struct {
+				Code    string                   `json:"code"`
+				Details api.NotAcceptableDetails `json:"details"`
+			} `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope))
+		assert.Equal(t, api.ErrCodeNotAcceptable, envelope.Error.Code)
+		assert.Contains(t, envelope.Error.Details.Supported, "text/csv")
+	})
+
+	t.Run("errors fall back to json", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		api.WriteError(res, req, http.StatusNotFound, api.ErrCodeNotFound, "pokemon not found")
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+	})
+}
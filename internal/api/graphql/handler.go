@@ -0,0 +1,100 @@
+package graphql
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName"`
+}
+
+// Handler serves POST /api/graphql. Responses follow the GraphQL spec rather than the envelope of the api:
+// requests that cannot be executed are answered with 400 and only errors, the others with 200 and data.
+func Handler(resolvers Resolvers, limits Limits) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var gqlReq Request
+		if err := json.NewDecoder(req.Body).Decode(&gqlReq); err != nil {
+			status, _ := api.BodyErrorStatus(err)
+			writeErrors(w, status, "invalid request body: "+err.Error())
+			return
+		}
+
+		doc, err := parser.Parse(parser.ParseParams{
+			Source: source.NewSource(&source.Source{Body: []byte(gqlReq.Query), Name: "GraphQL request"}),
+		})
+		if err != nil {
+			writeResult(w, http.StatusBadRequest, &gql.Result{Errors: gqlerrors.FormatErrors(err)})
+			return
+		}
+
+		if validation := gql.ValidateDocument(&schema, doc, nil); !validation.IsValid {
+			writeResult(w, http.StatusBadRequest, &gql.Result{Errors: validation.Errors})
+			return
+		}
+
+		a := analyze(doc, gqlReq.Variables)
+		if err := a.check(limits); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := withLoaders(req.Context(), resolvers, limits.MaxNames, service.PokemonOptions{Include: a.include})
+		result := gql.Execute(gql.ExecuteParams{
+			Schema:        schema,
+			AST:           doc,
+			Args:          gqlReq.Variables,
+			OperationName: gqlReq.OperationName,
+			Context:       ctx,
+		})
+
+		for i := range result.Errors {
+			extendError(&result.Errors[i])
+		}
+		writeResult(w, http.StatusOK, result)
+	}
+}
+
+// extendError restores the extensions of the errors returned by thunks, which the executor drops
+// as it wraps them once more than the errors returned by resolvers.
+func extendError(e *gqlerrors.FormattedError) {
+	var err error = *e
+	for e.Extensions == nil && err != nil {
+		switch typed := err.(type) {
+		case gqlerrors.ExtendedError:
+			e.Extensions = typed.Extensions()
+		case gqlerrors.FormattedError:
+			err = typed.OriginalError()
+		case *gqlerrors.Error:
+			err = typed.OriginalError
+		default:
+			return
+		}
+	}
+}
+
+func writeErrors(w http.ResponseWriter, status int, message string) {
+	err := gqlerrors.NewFormattedError(message)
+	err.Extensions = map[string]any{"code": api.ErrCodeBadRequest}
+	writeResult(w, status, &gql.Result{Errors: []gqlerrors.FormattedError{err}})
+}
+
+func writeResult(w http.ResponseWriter, status int, result *gql.Result) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Printf("failed to write json: %v", err)
+	}
+}
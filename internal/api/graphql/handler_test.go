@@ -0,0 +1,222 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api/graphql"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pokedex = map[string]model.Pokemon{
+	"bulbasaur": {
+		ID:           1,
+		Name:         "bulbasaur",
+		Description:  "A seed pokemon.",
+		Descriptions: map[string]string{"en": "A seed pokemon.", "fr": "Un pokemon graine."},
+		IsLegendary:  client.BoolPtr(false),
+	},
+	"ivysaur":    {ID: 2, Name: "ivysaur", Description: "A bigger seed pokemon.", IsLegendary: client.BoolPtr(false)},
+	"charmander": {ID: 4, Name: "charmander", Description: "A lizard pokemon.", IsLegendary: client.BoolPtr(false)},
+}
+
+var chains = map[string]model.EvolutionChain{
+	"bulbasaur": {ID: 1, Chain: model.EvolutionNode{
+		Name:      "bulbasaur",
+		EvolvesTo: []model.EvolutionNode{{Name: "ivysaur", Conditions: []model.EvolutionCondition{{Trigger: "level-up", MinLevel: 16}}}},
+	}},
+	"charmander": {ID: 2, Chain: model.EvolutionNode{Name: "charmander"}},
+}
+
+type graphqlResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func newHandler(batches *atomic.Int32, limits graphql.Limits) func(w http.ResponseWriter, req *http.Request) {
+	return graphql.Handler(graphql.Resolvers{
+		GetPokemonBatch: func(ctx context.Context, names []string, opts service.PokemonOptions) []service.PokemonResult {
+			batches.Add(1)
+			results := make([]service.PokemonResult, 0, len(names))
+			for _, n := range names {
+				p, ok := pokedex[n]
+				if !ok {
+					results = append(results, service.PokemonResult{Name: n, Err: service.ErrNotFound})
+					continue
+				}
+				results = append(results, service.PokemonResult{Name: n, Pokemon: p})
+			}
+			return results
+		},
+		GetEvolutions: func(ctx context.Context, name string) (model.EvolutionChain, error) {
+			return chains[name], nil
+		},
+		Describe: service.PokemonDescriptionService(func(ctx context.Context, style service.TranslationStyle, text string) (string, error) {
+			return string(style) + ": " + text, nil
+		}),
+	}, limits)
+}
+
+func query(t *testing.T, h func(w http.ResponseWriter, req *http.Request), q string) (int, graphqlResponse) {
+	return queryVariables(t, h, q, nil)
+}
+
+func queryVariables(t *testing.T, h func(w http.ResponseWriter, req *http.Request), q string, variables map[string]any) (int, graphqlResponse) {
+	body, err := json.Marshal(graphql.Request{Query: q, Variables: variables})
+	require.NoError(t, err)
+
+	res := httptest.NewRecorder()
+	h(res, httptest.NewRequest("POST", "/api/graphql", strings.NewReader(string(body))))
+
+	var response graphqlResponse
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response), "failed to unmarshal response")
+	return res.Code, response
+}
+
+var defaultLimits = graphql.Limits{MaxDepth: 6, MaxComplexity: 100, MaxNames: 5}
+
+func TestGraphQLQuery(t *testing.T) {
+	var batches atomic.Int32
+	h := newHandler(&batches, defaultLimits)
+
+	status, response := query(t, h, `{
+		pokemon(name: "bulbasaur") {
+			name
+			description(style: YODA, lang: "fr")
+			evolutions { chain { evolvesTo { name conditions { trigger minLevel item } pokemon { id } } } }
+		}
+	}`)
+
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, response.Errors)
+	expected := `{
+		"pokemon": {
+			"name": "bulbasaur",
+			"description": "yoda: Un pokemon graine.",
+			"evolutions": {"chain": {"evolvesTo": [
+				{"name": "ivysaur", "conditions": [{"trigger": "level-up", "minLevel": 16, "item": null}], "pokemon": {"id": 2}}
+			]}}
+		}
+	}`
+	raw, _ := json.Marshal(response.Data)
+	assert.JSONEq(t, expected, string(raw))
+}
+
+func TestGraphQLBatching(t *testing.T) {
+	var batches atomic.Int32
+	h := newHandler(&batches, defaultLimits)
+
+	status, response := query(t, h, `{
+		pokemons(names: ["bulbasaur", "charmander"]) {
+			name
+			evolutions { chain { pokemon { name } } }
+		}
+	}`)
+
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, response.Errors)
+	assert.Len(t, response.Data["pokemons"], 2)
+	assert.Equal(t, int32(1), batches.Load(), "pokemon already loaded by the request are not fetched again")
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	var batches atomic.Int32
+	h := newHandler(&batches, defaultLimits)
+
+	tests := []struct {
+		name           string
+		query          string
+		variables      map[string]any
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "pokemon not found",
+			query:          `{ pokemon(name: "missingno") { name } }`,
+			expectedStatus: http.StatusOK,
+			expectedCode:   "NOT_FOUND",
+		},
+		{
+			name:           "syntax error",
+			query:          `{ pokemon(name: "pikachu") { name }`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown field",
+			query:          `{ pokemon(name: "pikachu") { color } }`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "too deep",
+			query:          `{ pokemon(name: "bulbasaur") { evolutions { chain { evolvesTo { evolvesTo { evolvesTo { name } } } } } } }`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "BAD_REQUEST",
+		},
+		{
+			name:           "too complex",
+			query:          `{ pokemons(names: ["a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"]) { name } }`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "BAD_REQUEST",
+		},
+		{
+			name:           "too complex with variables",
+			query:          `query($names: [String!]!) { pokemons(names: $names) { name } }`,
+			variables:      map[string]any{"names": []any{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "BAD_REQUEST",
+		},
+		{
+			name:           "translations of many pokemon too complex",
+			query:          `{ pokemons(names: ["a", "b", "c", "d", "e", "f"]) { description(style: YODA) } }`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "BAD_REQUEST",
+		},
+		{
+			name:           "too many names",
+			query:          `{ pokemons(names: ["a", "b", "c", "d", "e", "f"]) { name } }`,
+			expectedStatus: http.StatusOK,
+			expectedCode:   "BAD_REQUEST",
+		},
+		{
+			name:           "introspection disabled",
+			query:          `{ __schema { queryType { name } } }`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "BAD_REQUEST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := queryVariables(t, h, tt.query, tt.variables)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			require.NotEmpty(t, response.Errors)
+			if tt.expectedCode != "" {
+				assert.Equal(t, tt.expectedCode, response.Errors[0].Extensions["code"])
+			}
+		})
+	}
+}
+
+func TestGraphQLIntrospection(t *testing.T) {
+	var batches atomic.Int32
+	h := newHandler(&batches, graphql.Limits{MaxDepth: 2, MaxComplexity: 1, Introspection: true})
+
+	status, response := query(t, h, `{ __schema { queryType { name } } }`)
+
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, response.Errors)
+	assert.Equal(t, map[string]any{"queryType": map[string]any{"name": "Query"}}, response.Data["__schema"])
+}
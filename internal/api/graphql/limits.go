@@ -0,0 +1,198 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+var errIntrospectionDisabled = errors.New("introspection is disabled")
+
+// limitError is a limit exceeded by the arguments of a field.
+type limitError string
+
+func (e limitError) Error() string {
+	return string(e)
+}
+
+func (e limitError) Extensions() map[string]any {
+	return map[string]any{"code": api.ErrCodeBadRequest}
+}
+
+// Limits protect the upstream APIs from expensive queries.
+type Limits struct {
+	// MaxDepth is the maximum nesting of fields, e.g. 3 for { pokemon { evolutions { id } } }.
+	MaxDepth int
+	// MaxComplexity is the maximum cost of an operation, see fieldCost.
+	MaxComplexity int
+	// MaxNames is the maximum number of names of the pokemons field, usually the maximum batch size.
+	MaxNames int
+	// Introspection allows the __schema and __type queries.
+	Introspection bool
+}
+
+// upstreamFields are the fields resolved with upstream calls, they are more expensive than the others.
+var upstreamFields = map[string]bool{
+	"pokemon":    true,
+	"pokemons":   true,
+	"evolutions": true,
+}
+
+// fieldCost is 1 for plain fields and 10 for fields calling upstream APIs, translated descriptions included.
+// The cost of pokemons is multiplied by the number of names it is given, its fan out, as is the cost of
+// the fields it selects.
+func (a *analysis) fieldCost(f *ast.Field) (cost int, fanOut int) {
+	cost = 1
+	if upstreamFields[f.Name.Value] || (f.Name.Value == "description" && argument(f, "style") != nil) {
+		cost = 10
+	}
+	fanOut = 1
+	if names := argument(f, "names"); names != nil {
+		fanOut = max(a.listLen(names), 1)
+	}
+	return cost * fanOut, fanOut
+}
+
+// listLen returns the length of the list value v, given as a literal or as a variable.
+// A single value is coerced to a list of one value.
+func (a *analysis) listLen(v ast.Value) int {
+	switch v := v.(type) {
+	case *ast.ListValue:
+		return len(v.Values)
+	case *ast.Variable:
+		value, ok := a.variables[v.Name.Value]
+		if !ok {
+			if def := a.defaults[v.Name.Value]; def != nil {
+				return a.listLen(def)
+			}
+			return 0
+		}
+		switch value := value.(type) {
+		case []any:
+			return len(value)
+		case nil:
+			return 0
+		}
+	}
+	return 1
+}
+
+func argument(f *ast.Field, name string) ast.Value {
+	for _, a := range f.Arguments {
+		if a.Name.Value == name {
+			return a.Value
+		}
+	}
+	return nil
+}
+
+// analysis sums up the operations of a document, with their fragments expanded.
+type analysis struct {
+	depth         int
+	complexity    int
+	introspection bool
+	// include are the optional sections of pokemon data selected anywhere in the document, so that
+	// every pokemon of the request is loaded once with all of them.
+	include service.Include
+
+	fragments map[string]*ast.FragmentDefinition
+	visiting  map[string]bool
+	// variables are those of the request, defaults those of the operation walked
+	variables map[string]any
+	defaults  map[string]ast.Value
+}
+
+// analyze sums up doc, whose arguments may be variables of the request.
+func analyze(doc *ast.Document, variables map[string]any) analysis {
+	a := analysis{
+		fragments: map[string]*ast.FragmentDefinition{},
+		visiting:  map[string]bool{},
+		variables: variables,
+	}
+	for _, def := range doc.Definitions {
+		if f, ok := def.(*ast.FragmentDefinition); ok {
+			a.fragments[f.Name.Value] = f
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok {
+			a.defaults = map[string]ast.Value{}
+			for _, v := range op.VariableDefinitions {
+				a.defaults[v.Variable.Name.Value] = v.DefaultValue
+			}
+			a.depth = max(a.depth, a.walk(op.SelectionSet, 1, 1))
+		}
+	}
+	return a
+}
+
+// check returns why the document cannot be executed within limits, if any.
+func (a analysis) check(limits Limits) error {
+	switch {
+	case a.introspection && !limits.Introspection:
+		return errIntrospectionDisabled
+	case a.depth > limits.MaxDepth:
+		return fmt.Errorf("query depth %d exceeds the maximum of %d", a.depth, limits.MaxDepth)
+	case a.complexity > limits.MaxComplexity:
+		return fmt.Errorf("query complexity %d exceeds the maximum of %d", a.complexity, limits.MaxComplexity)
+	}
+	return nil
+}
+
+// walk visits the selections of set, whose fields sit at depth and are resolved times times, and returns
+// the depth of its deepest field.
+func (a *analysis) walk(set *ast.SelectionSet, depth int, times int) int {
+	deepest := 0
+	for _, sel := range set.Selections {
+		d := 0
+		switch s := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				// introspection is answered by the schema alone, it does not count towards the limits
+				a.introspection = a.introspection || s.Name.Value != "__typename"
+				continue
+			}
+			fanOut := a.visitField(s, times)
+			d = depth
+			if s.SelectionSet != nil {
+				d = max(d, a.walk(s.SelectionSet, depth+1, times*fanOut))
+			}
+		case *ast.InlineFragment:
+			d = a.walk(s.SelectionSet, depth, times)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment, ok := a.fragments[name]
+			if !ok || a.visiting[name] {
+				// unknown fragments and cycles are reported by the validation
+				continue
+			}
+			a.visiting[name] = true
+			d = a.walk(fragment.SelectionSet, depth, times)
+			a.visiting[name] = false
+		}
+		deepest = max(deepest, d)
+	}
+	return deepest
+}
+
+// visitField adds the cost of f, resolved times times, and returns its fan out, see fieldCost.
+func (a *analysis) visitField(f *ast.Field, times int) int {
+	cost, fanOut := a.fieldCost(f)
+	a.complexity += cost * times
+
+	switch f.Name.Value {
+	case "types":
+		a.include.Types = true
+	case "abilities":
+		a.include.Abilities = true
+	case "stats", "height", "weight":
+		a.include.Stats = true
+	}
+	return fanOut
+}
@@ -0,0 +1,126 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type result[V any] struct {
+	value V
+	err   error
+}
+
+// loader batches the lookups of a request. Resolvers queue keys with load and get back a thunk:
+// the executor only calls thunks once every field of the current level is resolved, so the first
+// call fetches all the keys queued so far at once. Results are kept for the rest of the request.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) map[string]result[V]
+
+	mu      sync.Mutex
+	pending []string
+	done    map[string]result[V]
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) map[string]result[V]) *loader[V] {
+	return &loader[V]{fetch: fetch, done: make(map[string]result[V])}
+}
+
+func (l *loader[V]) load(ctx context.Context, key string) func() (V, error) {
+	l.mu.Lock()
+	if _, ok := l.done[key]; !ok {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, ok := l.done[key]; !ok && len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+			for k, r := range l.fetch(ctx, keys) {
+				l.done[k] = r
+			}
+		}
+
+		r := l.done[key]
+		return r.value, r.err
+	}
+}
+
+// loaders are the loaders of a single request, along with the resolvers they are built on.
+type loaders struct {
+	resolvers  Resolvers
+	maxNames   int
+	pokemon    *loader[model.Pokemon]
+	evolutions *loader[model.EvolutionChain]
+}
+
+type loadersKey struct{}
+
+// withLoaders returns ctx carrying the loaders of a request, loading the pokemon with opts.
+func withLoaders(ctx context.Context, r Resolvers, maxNames int, opts service.PokemonOptions) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		resolvers: r,
+		maxNames:  maxNames,
+		pokemon: newLoader(func(ctx context.Context, names []string) map[string]result[model.Pokemon] {
+			names = dedupe(names)
+			results := make(map[string]result[model.Pokemon], len(names))
+			for i, r := range r.GetPokemonBatch(ctx, names, opts) {
+				results[names[i]] = result[model.Pokemon]{value: r.Pokemon, err: r.Err}
+			}
+			return results
+		}),
+		evolutions: newLoader(func(ctx context.Context, names []string) map[string]result[model.EvolutionChain] {
+			return fetchEach(ctx, dedupe(names), r.GetEvolutions)
+		}),
+	})
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// fetchEach looks up every key concurrently, for the lookups that have no batch variant.
+func fetchEach[V any](
+	ctx context.Context,
+	keys []string,
+	lookup func(ctx context.Context, key string) (V, error),
+) map[string]result[V] {
+	results := make(map[string]result[V], len(keys))
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := lookup(ctx, key)
+
+			mu.Lock()
+			results[key] = result[V]{value: v, err: err}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func dedupe(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	unique := keys[:0:0]
+	for _, k := range keys {
+		if !seen[k] {
+			seen[k] = true
+			unique = append(unique, k)
+		}
+	}
+	return unique
+}
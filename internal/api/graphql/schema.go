@@ -0,0 +1,239 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	gql "github.com/graphql-go/graphql"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+// Resolvers are the service functions the schema is resolved with.
+type Resolvers struct {
+	GetPokemonBatch handler.PokemonBatchGetter
+	GetEvolutions   handler.EvolutionGetter
+	Describe        func(ctx context.Context, p model.Pokemon, lang string, style service.TranslationStyle) (string, bool)
+}
+
+// schema is static, the resolvers of a request are found in its context.
+var schema = mustSchema()
+
+// resolverError exposes the error code of the api in the extensions of a GraphQL error.
+type resolverError struct {
+	err error
+}
+
+func (e resolverError) Error() string {
+	return e.err.Error()
+}
+
+func (e resolverError) Extensions() map[string]any {
+	_, code := handler.ErrorStatus(e.err)
+	return map[string]any{"code": code}
+}
+
+// resolveField resolves a field of a source of type S with get.
+func resolveField[S any](t gql.Output, get func(s S) any) *gql.Field {
+	return &gql.Field{
+		Type: t,
+		Resolve: func(p gql.ResolveParams) (any, error) {
+			return get(p.Source.(S)), nil
+		},
+	}
+}
+
+// nonZero turns the zero values meaning "none" in the model into nulls.
+func nonZero[T comparable](v T) any {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return v
+}
+
+// loadPokemon resolves to the pokemon called name, batched with the other pokemon of the request.
+func loadPokemon(ctx context.Context, name string) func() (any, error) {
+	thunk := loadersFromContext(ctx).pokemon.load(ctx, name)
+	return func() (any, error) {
+		p, err := thunk()
+		if err != nil {
+			return nil, resolverError{err}
+		}
+		return p, nil
+	}
+}
+
+func mustSchema() gql.Schema {
+	translationStyle := gql.NewEnum(gql.EnumConfig{
+		Name: "TranslationStyle",
+		Values: gql.EnumValueConfigMap{
+			"AUTO":        {Value: service.Auto, Description: "Yoda for legendary and cave pokemon, Shakespeare for the others"},
+			"YODA":        {Value: service.Yoda},
+			"SHAKESPEARE": {Value: service.TranslationStyle(service.Shakespeare)},
+		},
+	})
+
+	stat := gql.NewObject(gql.ObjectConfig{
+		Name: "Stat",
+		Fields: gql.Fields{
+			"name":     resolveField(gql.NewNonNull(gql.String), func(s model.Stat) any { return s.Name }),
+			"baseStat": resolveField(gql.NewNonNull(gql.Int), func(s model.Stat) any { return s.BaseStat }),
+		},
+	})
+
+	ability := gql.NewObject(gql.ObjectConfig{
+		Name: "Ability",
+		Fields: gql.Fields{
+			"name":     resolveField(gql.NewNonNull(gql.String), func(a model.Ability) any { return a.Name }),
+			"isHidden": resolveField(gql.NewNonNull(gql.Boolean), func(a model.Ability) any { return a.IsHidden }),
+		},
+	})
+
+	evolutionCondition := gql.NewObject(gql.ObjectConfig{
+		Name:        "EvolutionCondition",
+		Description: "How an evolution is triggered, null fields are not required.",
+		Fields: gql.Fields{
+			"trigger":            resolveField(gql.String, func(c model.EvolutionCondition) any { return nonZero(c.Trigger) }),
+			"minLevel":           resolveField(gql.Int, func(c model.EvolutionCondition) any { return nonZero(c.MinLevel) }),
+			"item":               resolveField(gql.String, func(c model.EvolutionCondition) any { return nonZero(c.Item) }),
+			"heldItem":           resolveField(gql.String, func(c model.EvolutionCondition) any { return nonZero(c.HeldItem) }),
+			"minHappiness":       resolveField(gql.Int, func(c model.EvolutionCondition) any { return nonZero(c.MinHappiness) }),
+			"minAffection":       resolveField(gql.Int, func(c model.EvolutionCondition) any { return nonZero(c.MinAffection) }),
+			"minBeauty":          resolveField(gql.Int, func(c model.EvolutionCondition) any { return nonZero(c.MinBeauty) }),
+			"timeOfDay":          resolveField(gql.String, func(c model.EvolutionCondition) any { return nonZero(c.TimeOfDay) }),
+			"knownMove":          resolveField(gql.String, func(c model.EvolutionCondition) any { return nonZero(c.KnownMove) }),
+			"knownMoveType":      resolveField(gql.String, func(c model.EvolutionCondition) any { return nonZero(c.KnownMoveType) }),
+			"location":           resolveField(gql.String, func(c model.EvolutionCondition) any { return nonZero(c.Location) }),
+			"tradeSpecies":       resolveField(gql.String, func(c model.EvolutionCondition) any { return nonZero(c.TradeSpecies) }),
+			"needsOverworldRain": resolveField(gql.Boolean, func(c model.EvolutionCondition) any { return c.NeedsOverworldRain }),
+		},
+	})
+
+	// pokemon and evolution refer to each other, hence the fields thunks
+	var pokemon, evolution *gql.Object
+
+	evolution = gql.NewObject(gql.ObjectConfig{
+		Name:        "Evolution",
+		Description: "A species of an evolution chain.",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"name": resolveField(gql.NewNonNull(gql.String), func(n model.EvolutionNode) any { return n.Name }),
+				"pokemon": &gql.Field{
+					Type: pokemon,
+					Resolve: func(p gql.ResolveParams) (any, error) {
+						return loadPokemon(p.Context, p.Source.(model.EvolutionNode).Name), nil
+					},
+				},
+				"conditions": resolveField(
+					gql.NewNonNull(gql.NewList(gql.NewNonNull(evolutionCondition))),
+					func(n model.EvolutionNode) any { return n.Conditions },
+				),
+				"evolvesTo": resolveField(
+					gql.NewNonNull(gql.NewList(gql.NewNonNull(evolution))),
+					func(n model.EvolutionNode) any { return n.EvolvesTo },
+				),
+			}
+		}),
+	})
+
+	evolutionChain := gql.NewObject(gql.ObjectConfig{
+		Name: "EvolutionChain",
+		Fields: gql.Fields{
+			"id":    resolveField(gql.NewNonNull(gql.Int), func(c model.EvolutionChain) any { return c.ID }),
+			"chain": resolveField(gql.NewNonNull(evolution), func(c model.EvolutionChain) any { return c.Chain }),
+		},
+	})
+
+	pokemon = gql.NewObject(gql.ObjectConfig{
+		Name: "Pokemon",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id":   resolveField(gql.NewNonNull(gql.Int), func(p model.Pokemon) any { return p.ID }),
+				"name": resolveField(gql.NewNonNull(gql.String), func(p model.Pokemon) any { return p.Name }),
+				"description": &gql.Field{
+					Type:        gql.String,
+					Description: "The description in lang, english by default, translated when a style is given.",
+					Args: gql.FieldConfigArgument{
+						"style": {Type: translationStyle},
+						"lang":  {Type: gql.String, DefaultValue: "en"},
+					},
+					Resolve: func(p gql.ResolveParams) (any, error) {
+						style, _ := p.Args["style"].(service.TranslationStyle)
+						lang, _ := p.Args["lang"].(string)
+						describe := loadersFromContext(p.Context).resolvers.Describe
+						if description, ok := describe(p.Context, p.Source.(model.Pokemon), lang, style); ok {
+							return description, nil
+						}
+						return nil, nil
+					},
+				},
+				"habitat":     resolveField(gql.String, func(p model.Pokemon) any { return nonZero(p.Habitat) }),
+				"isLegendary": resolveField(gql.Boolean, func(p model.Pokemon) any { return p.IsLegendary }),
+				"types":       resolveField(gql.NewList(gql.NewNonNull(gql.String)), func(p model.Pokemon) any { return p.Types }),
+				"stats":       resolveField(gql.NewList(gql.NewNonNull(stat)), func(p model.Pokemon) any { return p.Stats }),
+				"abilities":   resolveField(gql.NewList(gql.NewNonNull(ability)), func(p model.Pokemon) any { return p.Abilities }),
+				"height":      resolveField(gql.Int, func(p model.Pokemon) any { return nonZero(p.Height) }),
+				"weight":      resolveField(gql.Int, func(p model.Pokemon) any { return nonZero(p.Weight) }),
+				"unavailable": resolveField(
+					gql.NewList(gql.NewNonNull(gql.String)),
+					func(p model.Pokemon) any { return p.Unavailable },
+				),
+				"evolutions": &gql.Field{
+					Type: evolutionChain,
+					Resolve: func(p gql.ResolveParams) (any, error) {
+						name := p.Source.(model.Pokemon).Name
+						thunk := loadersFromContext(p.Context).evolutions.load(p.Context, name)
+						return func() (any, error) {
+							chain, err := thunk()
+							if err != nil {
+								return nil, resolverError{err}
+							}
+							return chain, nil
+						}, nil
+					},
+				},
+			}
+		}),
+	})
+
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"pokemon": &gql.Field{
+				Type: pokemon,
+				Args: gql.FieldConfigArgument{
+					"name": {Type: gql.NewNonNull(gql.String), Description: "Name or national pokedex ID"},
+				},
+				Resolve: func(p gql.ResolveParams) (any, error) {
+					return loadPokemon(p.Context, p.Args["name"].(string)), nil
+				},
+			},
+			"pokemons": &gql.Field{
+				Type: gql.NewList(pokemon),
+				Args: gql.FieldConfigArgument{
+					"names": {Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String)))},
+				},
+				Resolve: func(p gql.ResolveParams) (any, error) {
+					names := p.Args["names"].([]any)
+					if maxNames := loadersFromContext(p.Context).maxNames; maxNames > 0 && len(names) > maxNames {
+						return nil, limitError(fmt.Sprintf("too many names: maximum batch size is %d", maxNames))
+					}
+					thunks := make([]any, 0, len(names))
+					for _, name := range names {
+						thunks = append(thunks, loadPokemon(p.Context, name.(string)))
+					}
+					return thunks, nil
+				},
+			},
+		},
+	})
+
+	s, err := gql.NewSchema(gql.SchemaConfig{Query: query})
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type PokemonBatchGetter func(ctx context.Context, names []string, opts service.PokemonOptions) []service.PokemonResult
+
+type BatchRequest struct {
+	Names      []string `json:"names"`
+	Translated bool     `json:"translated"`
+}
+
+// BatchItem holds either the pokemon or the error for a single name of the batch.
+type BatchItem struct {
+	Name    string     `json:"name"`
+	Pokemon *Pokemon   `json:"pokemon,omitempty"`
+	Error   *api.Error `json:"error,omitempty"`
+}
+
+// GetPokemonBatch serves both POST /api/pokemon/batch (JSON body) and GET /api/pokemon?names=a,b,c.
+// The response is always 200 when the request is valid: failures are reported per item.
+func GetPokemonBatch(
+	getBatch PokemonBatchGetter,
+	getBatchTranslated PokemonBatchGetter,
+	maxBatchSize int,
+) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		batchReq, err := parseBatchRequest(req)
+		if err != nil {
+			status, code := api.BodyErrorStatus(err)
+			api.WriteError(w, req, status, code, err.Error())
+			return
+		}
+
+		names, err := service.BatchNames(batchReq.Names, maxBatchSize)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		opts, err := pokemonOptions(req)
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		getter := getBatch
+		if batchReq.Translated {
+			getter = getBatchTranslated
+		}
+
+		results := getter(req.Context(), names, opts)
+
+		items := make([]BatchItem, 0, len(results))
+		for _, r := range results {
+			items = append(items, batchItemMapper(r, opts))
+		}
+
+		api.WriteData(w, req, items, http.StatusOK)
+	}
+}
+
+func parseBatchRequest(req *http.Request) (BatchRequest, error) {
+	if req.Method == http.MethodPost {
+		var batchReq BatchRequest
+		if err := json.NewDecoder(req.Body).Decode(&batchReq); err != nil {
+			return BatchRequest{}, fmt.Errorf("invalid request body: %w", err)
+		}
+		return batchReq, nil
+	}
+
+	query := req.URL.Query()
+	translated, err := parseBoolParam(query, "translated")
+	if err != nil {
+		return BatchRequest{}, err
+	}
+
+	return BatchRequest{
+		Names:      strings.Split(query.Get("names"), ","),
+		Translated: translated,
+	}, nil
+}
+
+func batchItemMapper(r service.PokemonResult, opts service.PokemonOptions) BatchItem {
+	if r.Err != nil {
+		_, code := ErrorStatus(r.Err)
+		return BatchItem{
+			Name:  r.Name,
+			Error: &api.Error{Code: code, Message: r.Err.Error(), Details: errorDetails(r.Err)},
+		}
+	}
+
+	pokemon := mapper(r.Pokemon, opts)
+	return BatchItem{Name: r.Name, Pokemon: &pokemon}
+}
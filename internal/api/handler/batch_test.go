@@ -0,0 +1,113 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPokemonBatch(t *testing.T) {
+	getBatch := func(ctx context.Context, names []string, opts service.PokemonOptions) []service.PokemonResult {
+		results := make([]service.PokemonResult, 0, len(names))
+		for _, n := range names {
+			if n == "missingno" {
+				results = append(results, service.PokemonResult{Name: n, Err: service.ErrNotFound})
+				continue
+			}
+			results = append(results, service.PokemonResult{Name: n, Pokemon: model.Pokemon{
+				Name:        n,
+				Description: "description",
+				Habitat:     "forest",
+				IsLegendary: client.BoolPtr(false),
+			}})
+		}
+		return results
+	}
+	getBatchTranslated := func(ctx context.Context, names []string, opts service.PokemonOptions) []service.PokemonResult {
+		results := getBatch(ctx, names, opts)
+		for i := range results {
+			results[i].Pokemon.Description = "translated"
+		}
+		return results
+	}
+
+	tests := []struct {
+		name               string
+		request            *http.Request
+		expectedStatusCode int
+		expectedItems      []handler.BatchItem
+	}{
+		{
+			name:               "GET /api/pokemon?names= partial success",
+			request:            httptest.NewRequest("GET", "/api/pokemon?names=pikachu,%20missingno", nil),
+			expectedStatusCode: http.StatusOK,
+			expectedItems: []handler.BatchItem{
+				{Name: "pikachu", Pokemon: &handler.Pokemon{Name: "pikachu", Description: "description", Habitat: "forest", IsLegendary: client.BoolPtr(false)}},
+				{Name: "missingno", Error: &api.Error{Code: api.ErrCodeNotFound, Message: service.ErrNotFound.Error()}},
+			},
+		},
+		{
+			name:               "POST /api/pokemon/batch translated",
+			request:            httptest.NewRequest("POST", "/api/pokemon/batch", strings.NewReader(`{"names":["pikachu"],"translated":true}`)),
+			expectedStatusCode: http.StatusOK,
+			expectedItems: []handler.BatchItem{
+				{Name: "pikachu", Pokemon: &handler.Pokemon{Name: "pikachu", Description: "translated", Habitat: "forest", IsLegendary: client.BoolPtr(false)}},
+			},
+		},
+		{
+			name:               "GET /api/pokemon missing names",
+			request:            httptest.NewRequest("GET", "/api/pokemon?names=,", nil),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "GET /api/pokemon invalid translated flag",
+			request:            httptest.NewRequest("GET", "/api/pokemon?names=pikachu&translated=maybe", nil),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "POST /api/pokemon/batch too many names",
+			request:            httptest.NewRequest("POST", "/api/pokemon/batch", strings.NewReader(`{"names":["a","b","c","d"]}`)),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "POST /api/pokemon/batch invalid body",
+			request:            httptest.NewRequest("POST", "/api/pokemon/batch", strings.NewReader(`{"names":`)),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getPokemonBatchHandler := handler.GetPokemonBatch(getBatch, getBatchTranslated, 3)
+
+			res := httptest.NewRecorder()
+			getPokemonBatchHandler(res, tt.request)
+
+			assert.Equal(t, tt.expectedStatusCode, res.Code)
+
+			var envelope struct {
+				Data  []handler.BatchItem `json:"data"`
+				Error *api.Error          `json:"error"`
+			}
+			err := json.Unmarshal(res.Body.Bytes(), &envelope)
+			require.NoError(t, err, "failed to unmarshal response")
+
+			if tt.expectedStatusCode != http.StatusOK {
+				assert.NotNil(t, envelope.Error)
+				return
+			}
+			assert.Equal(t, tt.expectedItems, envelope.Data)
+		})
+	}
+}
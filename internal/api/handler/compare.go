@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type PokemonComparer func(ctx context.Context, a, b string) (service.Comparison, error)
+
+// Comparison holds both pokemon, or their error, and the diff when both were found.
+type Comparison struct {
+	A    BatchItem       `json:"a"`
+	B    BatchItem       `json:"b"`
+	Diff *ComparisonDiff `json:"diff,omitempty"`
+}
+
+type ComparisonDiff struct {
+	Stats     []StatDelta        `json:"stats"`
+	Types     TypesDiff          `json:"types"`
+	Habitat   ValuesDiff[string] `json:"habitat"`
+	Legendary ValuesDiff[bool]   `json:"legendary"`
+	Matchups  Matchups           `json:"matchups"`
+}
+
+type StatDelta struct {
+	Name  string `json:"name"`
+	A     int    `json:"a"`
+	B     int    `json:"b"`
+	Delta int    `json:"delta"`
+}
+
+type TypesDiff struct {
+	Shared []string `json:"shared"`
+	OnlyA  []string `json:"onlyA"`
+	OnlyB  []string `json:"onlyB"`
+}
+
+type ValuesDiff[T comparable] struct {
+	A    T    `json:"a"`
+	B    T    `json:"b"`
+	Same bool `json:"same"`
+}
+
+// Matchups lists the multiplier of each type of one pokemon attacking the other.
+type Matchups struct {
+	AVersusB []TypeMultiplier `json:"aVersusB"`
+	BVersusA []TypeMultiplier `json:"bVersusA"`
+}
+
+// ComparePokemon serves GET /api/pokemon/compare?a=&b=.
+// When only one pokemon is found the response is still 200, with the error of the other one and no diff.
+func ComparePokemon(compare PokemonComparer) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		a, b := strings.TrimSpace(query.Get("a")), strings.TrimSpace(query.Get("b"))
+		if a == "" || b == "" {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, "missing a or b parameter")
+			return
+		}
+
+		opts, err := pokemonOptions(req)
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		c, err := compare(req.Context(), a, b)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		comparison := Comparison{
+			A: batchItemMapper(c.A, opts),
+			B: batchItemMapper(c.B, opts),
+		}
+		if c.Complete() {
+			comparison.Diff = comparisonDiffMapper(c)
+		}
+
+		api.WriteData(w, req, comparison, http.StatusOK)
+	}
+}
+
+func comparisonDiffMapper(c service.Comparison) *ComparisonDiff {
+	pa, pb := c.A.Pokemon, c.B.Pokemon
+
+	diff := &ComparisonDiff{
+		Stats: make([]StatDelta, 0, len(c.StatDeltas)),
+		Types: TypesDiff{
+			Shared: append([]string{}, c.SharedTypes...),
+			OnlyA:  append([]string{}, c.TypesOnlyA...),
+			OnlyB:  append([]string{}, c.TypesOnlyB...),
+		},
+		Habitat: ValuesDiff[string]{A: pa.Habitat, B: pb.Habitat, Same: pa.Habitat == pb.Habitat},
+		Matchups: Matchups{
+			AVersusB: typeMultipliersMapper(c.AVersusB),
+			BVersusA: typeMultipliersMapper(c.BVersusA),
+		},
+	}
+	for _, d := range c.StatDeltas {
+		diff.Stats = append(diff.Stats, StatDelta(d))
+	}
+	if pa.IsLegendary != nil && pb.IsLegendary != nil {
+		diff.Legendary = ValuesDiff[bool]{A: *pa.IsLegendary, B: *pb.IsLegendary, Same: *pa.IsLegendary == *pb.IsLegendary}
+	}
+
+	return diff
+}
@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComparePokemon(t *testing.T) {
+	compare := func(ctx context.Context, a, b string) (service.Comparison, error) {
+		pikachu := service.PokemonResult{Name: a, Pokemon: model.Pokemon{Name: "pikachu", Habitat: "forest", IsLegendary: client.BoolPtr(false)}}
+		switch b {
+		case "raichu":
+			return service.Comparison{
+				A:           pikachu,
+				B:           service.PokemonResult{Name: b, Pokemon: model.Pokemon{Name: "raichu", Habitat: "forest", IsLegendary: client.BoolPtr(false)}},
+				StatDeltas:  []service.StatDelta{{Name: "hp", A: 35, B: 60, Delta: 25}},
+				SharedTypes: []string{"electric"},
+				AVersusB:    []model.TypeMultiplier{{Type: "electric", Multiplier: 0.5}},
+				BVersusA:    []model.TypeMultiplier{{Type: "electric", Multiplier: 0.5}},
+			}, nil
+		case "missingno":
+			return service.Comparison{A: pikachu, B: service.PokemonResult{Name: b, Err: service.ErrNotFound}}, nil
+		}
+		return service.Comparison{}, service.ErrNotFound
+	}
+	compareHandler := handler.ComparePokemon(compare)
+
+	get := func(t *testing.T, url string) (int, handler.Comparison, *api.Error) {
+		res := httptest.NewRecorder()
+		compareHandler(res, httptest.NewRequest("GET", url, nil))
+
+		var envelope struct {
+			Data  handler.Comparison `json:"data"`
+			Error *api.Error         `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+		return res.Code, envelope.Data, envelope.Error
+	}
+
+	t.Run("GET /api/pokemon/compare success", func(t *testing.T) {
+		status, c, _ := get(t, "/api/pokemon/compare?a=pikachu&b=raichu")
+
+		require.Equal(t, http.StatusOK, status)
+		require.NotNil(t, c.Diff)
+		assert.Equal(t, []handler.StatDelta{{Name: "hp", A: 35, B: 60, Delta: 25}}, c.Diff.Stats)
+		assert.Equal(t, []string{"electric"}, c.Diff.Types.Shared)
+		assert.True(t, c.Diff.Habitat.Same)
+		assert.True(t, c.Diff.Legendary.Same)
+		assert.Equal(t, []handler.TypeMultiplier{{Type: "electric", Multiplier: 0.5}}, c.Diff.Matchups.AVersusB)
+	})
+
+	t.Run("GET /api/pokemon/compare partial failure", func(t *testing.T) {
+		status, c, _ := get(t, "/api/pokemon/compare?a=pikachu&b=missingno")
+
+		require.Equal(t, http.StatusOK, status)
+		assert.Nil(t, c.Diff)
+		assert.NotNil(t, c.A.Pokemon)
+		require.NotNil(t, c.B.Error)
+		assert.Equal(t, api.ErrCodeNotFound, c.B.Error.Code)
+	})
+
+	t.Run("GET /api/pokemon/compare missing parameter", func(t *testing.T) {
+		status, _, apiErr := get(t, "/api/pokemon/compare?a=pikachu")
+
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.NotNil(t, apiErr)
+	})
+
+	t.Run("GET /api/pokemon/compare both not found", func(t *testing.T) {
+		status, _, apiErr := get(t, "/api/pokemon/compare?a=foo&b=bar")
+
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.NotNil(t, apiErr)
+	})
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+)
+
+// ConfigReload is the outcome of a reload of the configuration, listing the keys of the settings changed.
+type ConfigReload struct {
+	ReloadedAt time.Time `json:"reloadedAt"`
+	// Applied are the changes applied to the running application.
+	Applied []string `json:"applied"`
+	// RestartRequired are the changes only applied at the next restart, e.g. of the ports.
+	RestartRequired []string `json:"restartRequired"`
+}
+
+type ConfigReloader func(ctx context.Context) (ConfigReload, error)
+
+type ReloadErrorDetails struct {
+	Errors []string `json:"errors"`
+}
+
+// ReloadConfig serves POST /api/admin/config/reload. When the configuration is invalid nothing is applied,
+// and the response lists every error.
+func ReloadConfig(reload ConfigReloader) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		result, err := reload(req.Context())
+		if err != nil {
+			details := ReloadErrorDetails{Errors: []string{err.Error()}}
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				details.Errors = details.Errors[:0]
+				for _, e := range joined.Unwrap() {
+					details.Errors = append(details.Errors, e.Error())
+				}
+			}
+			api.WriteErrorDetails(w, req, http.StatusInternalServerError, api.ErrCodeInternal,
+				"failed to reload the configuration", details)
+			return
+		}
+
+		api.WriteData(w, req, result, http.StatusOK)
+	}
+}
+
+// ConfigReloadStats counts the reloads of the configuration since the start.
+type ConfigReloadStats struct {
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+	// LastReloadedAt is the time of the last reload that succeeded, if any.
+	LastReloadedAt *time.Time `json:"lastReloadedAt,omitempty"`
+}
+
+type ConfigReloadStatsGetter func(ctx context.Context) ConfigReloadStats
+
+// GetConfigReloads serves GET /api/admin/config/reloads.
+func GetConfigReloads(getStats ConfigReloadStatsGetter) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		api.WriteData(w, req, getStats(req.Context()), http.StatusOK)
+	}
+}
@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadConfig(t *testing.T) {
+	reloadedAt := time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		reload         handler.ConfigReloader
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "reloaded",
+			reload: func(ctx context.Context) (handler.ConfigReload, error) {
+				return handler.ConfigReload{ReloadedAt: reloadedAt, Applied: []string{"cache.ttl"}, RestartRequired: []string{}}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data": {"reloadedAt": "2024-05-04T10:00:00Z", "applied": ["cache.ttl"], "restartRequired": []}}`,
+		},
+		{
+			name: "invalid configuration",
+			reload: func(ctx context.Context) (handler.ConfigReload, error) {
+				return handler.ConfigReload{}, errors.Join(errors.New("invalid cache.ttl"), errors.New("missing upstreams.pokeapi.url"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: `{"error": {
+				"code": "INTERNAL_ERROR", "message": "failed to reload the configuration",
+				"details": {"errors": ["invalid cache.ttl", "missing upstreams.pokeapi.url"]}
+			}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			handler.ReloadConfig(tt.reload)(res, httptest.NewRequest("POST", "/api/admin/config/reload", nil))
+
+			require.Equal(t, tt.expectedStatus, res.Code)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			delete(body, "meta")
+			actual, _ := json.Marshal(body)
+			assert.JSONEq(t, tt.expectedBody, string(actual))
+		})
+	}
+}
+
+func TestGetConfigReloads(t *testing.T) {
+	reloadedAt := time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		stats        handler.ConfigReloadStats
+		expectedBody string
+	}{
+		{
+			name:         "never reloaded",
+			expectedBody: `{"data": {"succeeded": 0, "failed": 0}}`,
+		},
+		{
+			name:         "reloaded",
+			stats:        handler.ConfigReloadStats{Succeeded: 2, Failed: 1, LastReloadedAt: &reloadedAt},
+			expectedBody: `{"data": {"succeeded": 2, "failed": 1, "lastReloadedAt": "2024-05-04T10:00:00Z"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getStats := func(ctx context.Context) handler.ConfigReloadStats { return tt.stats }
+
+			res := httptest.NewRecorder()
+			handler.GetConfigReloads(getStats)(res, httptest.NewRequest("GET", "/api/admin/config/reloads", nil))
+
+			require.Equal(t, http.StatusOK, res.Code)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			delete(body, "meta")
+			actual, _ := json.Marshal(body)
+			assert.JSONEq(t, tt.expectedBody, string(actual))
+		})
+	}
+}
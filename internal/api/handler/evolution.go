@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+type EvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain Evolution `json:"chain"`
+}
+
+type Evolution struct {
+	Name       string               `json:"name"`
+	Conditions []EvolutionCondition `json:"conditions,omitempty"`
+	EvolvesTo  []Evolution          `json:"evolvesTo"`
+}
+
+type EvolutionCondition struct {
+	Trigger            string `json:"trigger"`
+	MinLevel           int    `json:"minLevel,omitempty"`
+	Item               string `json:"item,omitempty"`
+	HeldItem           string `json:"heldItem,omitempty"`
+	MinHappiness       int    `json:"minHappiness,omitempty"`
+	MinAffection       int    `json:"minAffection,omitempty"`
+	MinBeauty          int    `json:"minBeauty,omitempty"`
+	TimeOfDay          string `json:"timeOfDay,omitempty"`
+	KnownMove          string `json:"knownMove,omitempty"`
+	KnownMoveType      string `json:"knownMoveType,omitempty"`
+	Location           string `json:"location,omitempty"`
+	TradeSpecies       string `json:"tradeSpecies,omitempty"`
+	NeedsOverworldRain bool   `json:"needsOverworldRain,omitempty"`
+}
+
+type EvolutionGetter func(ctx context.Context, name string) (model.EvolutionChain, error)
+
+func GetPokemonEvolutions(getEvolutions EvolutionGetter) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		name := req.PathValue("name")
+		if name == "" {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, "missing name parameter")
+			return
+		}
+
+		chain, err := getEvolutions(req.Context(), name)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		api.WriteData(w, req, EvolutionChain{
+			ID:    chain.ID,
+			Chain: evolutionMapper(chain.Chain),
+		}, http.StatusOK)
+	}
+}
+
+func evolutionMapper(n model.EvolutionNode) Evolution {
+	evolution := Evolution{
+		Name:      n.Name,
+		EvolvesTo: make([]Evolution, 0, len(n.EvolvesTo)),
+	}
+	for _, c := range n.Conditions {
+		evolution.Conditions = append(evolution.Conditions, EvolutionCondition(c))
+	}
+	for _, next := range n.EvolvesTo {
+		evolution.EvolvesTo = append(evolution.EvolvesTo, evolutionMapper(next))
+	}
+	return evolution
+}
@@ -0,0 +1,66 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPokemonEvolutions(t *testing.T) {
+	getEvolutions := func(ctx context.Context, name string) (model.EvolutionChain, error) {
+		if name != "eevee" {
+			return model.EvolutionChain{}, service.ErrNotFound
+		}
+		return model.EvolutionChain{
+			ID: 67,
+			Chain: model.EvolutionNode{
+				Name: "eevee",
+				EvolvesTo: []model.EvolutionNode{
+					{Name: "vaporeon", Conditions: []model.EvolutionCondition{{Trigger: "use-item", Item: "water-stone"}}},
+					{Name: "espeon", Conditions: []model.EvolutionCondition{{Trigger: "level-up", MinHappiness: 160, TimeOfDay: "day"}}},
+				},
+			},
+		}, nil
+	}
+
+	t.Run("GET /api/pokemon/{name}/evolutions success", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/pokemon/eevee/evolutions", nil)
+		req.SetPathValue("name", "eevee")
+		res := httptest.NewRecorder()
+		handler.GetPokemonEvolutions(getEvolutions)(res, req)
+
+		require.Equal(t, http.StatusOK, res.Code)
+
+		var envelope struct {
+			Data handler.EvolutionChain `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+		assert.Equal(t, handler.EvolutionChain{
+			ID: 67,
+			Chain: handler.Evolution{
+				Name: "eevee",
+				EvolvesTo: []handler.Evolution{
+					{Name: "vaporeon", Conditions: []handler.EvolutionCondition{{Trigger: "use-item", Item: "water-stone"}}, EvolvesTo: []handler.Evolution{}},
+					{Name: "espeon", Conditions: []handler.EvolutionCondition{{Trigger: "level-up", MinHappiness: 160, TimeOfDay: "day"}}, EvolvesTo: []handler.Evolution{}},
+				},
+			},
+		}, envelope.Data)
+	})
+
+	t.Run("GET /api/pokemon/{name}/evolutions not found", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/pokemon/missingno/evolutions", nil)
+		req.SetPathValue("name", "missingno")
+		res := httptest.NewRecorder()
+		handler.GetPokemonEvolutions(getEvolutions)(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
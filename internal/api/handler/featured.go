@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type RandomPokemonGetter func(ctx context.Context, filter service.RandomFilter, opts service.PokemonOptions) (model.Pokemon, error)
+type DailyPokemonGetter func(ctx context.Context, date time.Time, opts service.PokemonOptions) (model.Pokemon, error)
+
+// GetRandomPokemon serves GET /api/pokemon/random?habitat=&legendary=&translated=.
+func GetRandomPokemon(
+	getRandom RandomPokemonGetter,
+	getRandomTranslated RandomPokemonGetter,
+) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+
+		filter := service.RandomFilter{Habitat: query.Get("habitat")}
+		if query.Has("legendary") {
+			legendary, err := parseBoolParam(query, "legendary")
+			if err != nil {
+				api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+				return
+			}
+			filter.Legendary = &legendary
+		}
+
+		translated, err := parseBoolParam(query, "translated")
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		opts, err := pokemonOptions(req)
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		getter := getRandom
+		if translated {
+			getter = getRandomTranslated
+		}
+
+		p, err := getter(req.Context(), filter, opts)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		api.WriteData(w, req, mapper(p, opts), http.StatusOK)
+	}
+}
+
+// GetDailyPokemon serves GET /api/pokemon/daily?translated=, the pokemon of the day of now().
+func GetDailyPokemon(
+	getDaily DailyPokemonGetter,
+	getDailyTranslated DailyPokemonGetter,
+	now func() time.Time,
+) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		translated, err := parseBoolParam(req.URL.Query(), "translated")
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		opts, err := pokemonOptions(req)
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		getter := getDaily
+		if translated {
+			getter = getDailyTranslated
+		}
+
+		p, err := getter(req.Context(), now(), opts)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		api.WriteData(w, req, mapper(p, opts), http.StatusOK)
+	}
+}
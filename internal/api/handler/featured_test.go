@@ -0,0 +1,93 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRandomPokemon(t *testing.T) {
+	randomWith := func(description string) handler.RandomPokemonGetter {
+		return func(ctx context.Context, filter service.RandomFilter, opts service.PokemonOptions) (model.Pokemon, error) {
+			switch {
+			case filter.Habitat == "moon":
+				return model.Pokemon{}, service.ErrHabitatNotFound
+			case filter.Legendary != nil && *filter.Legendary:
+				return model.Pokemon{Name: "mewtwo", Description: description, IsLegendary: client.BoolPtr(true)}, nil
+			default:
+				return model.Pokemon{Name: "zubat", Description: description, IsLegendary: client.BoolPtr(false)}, nil
+			}
+		}
+	}
+	randomHandler := handler.GetRandomPokemon(randomWith("plain"), randomWith("translated"))
+
+	tests := []struct {
+		name                string
+		query               string
+		expectedStatus      int
+		expectedName        string
+		expectedDescription string
+	}{
+		{name: "no filters", expectedStatus: http.StatusOK, expectedName: "zubat", expectedDescription: "plain"},
+		{name: "legendary", query: "?legendary=true", expectedStatus: http.StatusOK, expectedName: "mewtwo", expectedDescription: "plain"},
+		{name: "translated", query: "?translated=true", expectedStatus: http.StatusOK, expectedName: "zubat", expectedDescription: "translated"},
+		{name: "unknown habitat", query: "?habitat=moon", expectedStatus: http.StatusNotFound},
+		{name: "invalid legendary", query: "?legendary=sometimes", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			randomHandler(res, httptest.NewRequest("GET", "/api/pokemon/random"+tt.query, nil))
+
+			require.Equal(t, tt.expectedStatus, res.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, "no-store", res.Header().Get("Cache-Control"))
+			var envelope struct {
+				Data handler.Pokemon `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+			assert.Equal(t, tt.expectedName, envelope.Data.Name)
+			assert.Equal(t, tt.expectedDescription, envelope.Data.Description)
+		})
+	}
+}
+
+func TestGetDailyPokemon(t *testing.T) {
+	today := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	dailyWith := func(description string) handler.DailyPokemonGetter {
+		return func(ctx context.Context, date time.Time, opts service.PokemonOptions) (model.Pokemon, error) {
+			assert.Equal(t, today, date)
+			return model.Pokemon{Name: "pikachu", Description: description}, nil
+		}
+	}
+	dailyHandler := handler.GetDailyPokemon(dailyWith("plain"), dailyWith("translated"), func() time.Time { return today })
+
+	for query, expectedDescription := range map[string]string{"": "plain", "?translated=true": "translated"} {
+		t.Run("GET /api/pokemon/daily"+query, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			dailyHandler(res, httptest.NewRequest("GET", "/api/pokemon/daily"+query, nil))
+
+			require.Equal(t, http.StatusOK, res.Code)
+			var envelope struct {
+				Data handler.Pokemon `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+			assert.Equal(t, "pikachu", envelope.Data.Name)
+			assert.Equal(t, expectedDescription, envelope.Data.Description)
+		})
+	}
+}
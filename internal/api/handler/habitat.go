@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+type HabitatLister func(ctx context.Context, limit, offset int) (model.NamePage, error)
+type HabitatPokemonLister func(ctx context.Context, habitat string, limit, offset int) (model.NamePage, error)
+
+type Habitat struct {
+	Name string `json:"name"`
+}
+
+// ListHabitats serves GET /api/habitats?limit=&cursor=.
+func ListHabitats(listHabitats HabitatLister) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		limit, offset, err := parsePageRequest(req.URL.Query())
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		page, err := listHabitats(req.Context(), limit, offset)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		items := make([]Habitat, 0, len(page.Names))
+		for _, name := range page.Names {
+			items = append(items, Habitat{Name: name})
+		}
+
+		api.WriteDataPage(w, req, items, pagination(page), http.StatusOK)
+	}
+}
+
+// ListHabitatPokemon serves GET /api/habitats/{habitat}/pokemon?limit=&cursor=&expand=&translated=.
+// Items are hydrated like in ListPokemon; translated implies expand and uses getBatchTranslated instead of getBatch.
+func ListHabitatPokemon(
+	listMembers HabitatPokemonLister,
+	getBatch PokemonBatchGetter,
+	getBatchTranslated PokemonBatchGetter,
+) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+
+		expand, err := parseBoolParam(query, "expand")
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		translated, err := parseBoolParam(query, "translated")
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		getter := getBatch
+		if translated {
+			getter = getBatchTranslated
+			expand = true
+		}
+
+		habitat := req.PathValue("habitat")
+		list := func(ctx context.Context, limit, offset int) (model.NamePage, error) {
+			return listMembers(ctx, habitat, limit, offset)
+		}
+
+		writePokemonPage(w, req, list, getter, expand)
+	}
+}
@@ -0,0 +1,130 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListHabitats(t *testing.T) {
+	listHabitats := func(ctx context.Context, limit, offset int) (model.NamePage, error) {
+		return model.NamePage{Names: []string{"cave", "forest"}, Offset: offset, Limit: 2, Total: 9}, nil
+	}
+
+	res := httptest.NewRecorder()
+	handler.ListHabitats(listHabitats)(res, httptest.NewRequest("GET", "/api/habitats", nil))
+
+	require.Equal(t, http.StatusOK, res.Code)
+
+	var envelope struct {
+		Data []handler.Habitat `json:"data"`
+		Meta *api.Meta         `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+	assert.Equal(t, []handler.Habitat{{Name: "cave"}, {Name: "forest"}}, envelope.Data)
+	assert.Equal(t, 9, envelope.Meta.Pagination.Total)
+	assert.NotEmpty(t, envelope.Meta.Pagination.NextCursor)
+}
+
+func TestListHabitatPokemon(t *testing.T) {
+	listMembers := func(ctx context.Context, habitat string, limit, offset int) (model.NamePage, error) {
+		switch habitat {
+		case "cave":
+			return model.NamePage{Names: []string{"zubat", "golbat"}, Offset: offset, Limit: 2, Total: 2}, nil
+		case "moon":
+			return model.NamePage{}, service.ErrHabitatNotFound
+		default:
+			return model.NamePage{}, service.ErrInvalidHabitat
+		}
+	}
+	batchWith := func(description string) handler.PokemonBatchGetter {
+		return func(ctx context.Context, names []string, opts service.PokemonOptions) []service.PokemonResult {
+			results := make([]service.PokemonResult, 0, len(names))
+			for _, n := range names {
+				results = append(results, service.PokemonResult{Name: n, Pokemon: model.Pokemon{Name: n, Description: description}})
+			}
+			return results
+		}
+	}
+	listHandler := handler.ListHabitatPokemon(listMembers, batchWith("plain"), batchWith("translated"))
+
+	tests := []struct {
+		name           string
+		habitat        string
+		query          string
+		expectedStatus int
+		expectedItems  []handler.BatchItem
+	}{
+		{
+			name:           "names only",
+			habitat:        "cave",
+			expectedStatus: http.StatusOK,
+			expectedItems:  []handler.BatchItem{{Name: "zubat"}, {Name: "golbat"}},
+		},
+		{
+			name:           "expanded",
+			habitat:        "cave",
+			query:          "?expand=true",
+			expectedStatus: http.StatusOK,
+			expectedItems: []handler.BatchItem{
+				{Name: "zubat", Pokemon: &handler.Pokemon{Name: "zubat", Description: "plain"}},
+				{Name: "golbat", Pokemon: &handler.Pokemon{Name: "golbat", Description: "plain"}},
+			},
+		},
+		{
+			name:           "translated implies expand",
+			habitat:        "cave",
+			query:          "?translated=true",
+			expectedStatus: http.StatusOK,
+			expectedItems: []handler.BatchItem{
+				{Name: "zubat", Pokemon: &handler.Pokemon{Name: "zubat", Description: "translated"}},
+				{Name: "golbat", Pokemon: &handler.Pokemon{Name: "golbat", Description: "translated"}},
+			},
+		},
+		{
+			name:           "invalid translated parameter",
+			habitat:        "cave",
+			query:          "?translated=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown habitat",
+			habitat:        "moon",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid habitat",
+			habitat:        "m@@n",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/habitats/"+tt.habitat+"/pokemon"+tt.query, nil)
+			req.SetPathValue("habitat", tt.habitat)
+			res := httptest.NewRecorder()
+
+			listHandler(res, req)
+
+			require.Equal(t, tt.expectedStatus, res.Code)
+
+			var envelope listEnvelope
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+			if tt.expectedStatus != http.StatusOK {
+				assert.NotNil(t, envelope.Error)
+				return
+			}
+			assert.Equal(t, tt.expectedItems, envelope.Data)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type TranslationJobSubmitter func(
+	ctx context.Context,
+	names []string,
+	style service.TranslationStyle,
+	opts service.PokemonOptions,
+) (service.TranslationJob, error)
+type TranslationJobGetter func(ctx context.Context, id string) (service.TranslationJob, error)
+
+type TranslationJobRequest struct {
+	Names []string `json:"names"`
+	// Style is the translation style of every description: auto (default), yoda or shakespeare.
+	Style string `json:"style"`
+}
+
+type Job struct {
+	ID         string      `json:"id"`
+	Status     string      `json:"status"`
+	Style      string      `json:"style"`
+	Progress   JobProgress `json:"progress"`
+	CreatedAt  time.Time   `json:"createdAt"`
+	FinishedAt *time.Time  `json:"finishedAt,omitempty"`
+	Results    []BatchItem `json:"results"`
+}
+
+type JobProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// SubmitTranslationJob serves POST /api/jobs/translations. The job is run in the background:
+// the response is 202 with the job, whose status is then polled at the Location header.
+func SubmitTranslationJob(submit TranslationJobSubmitter, maxNames int) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var jobReq TranslationJobRequest
+		if err := json.NewDecoder(req.Body).Decode(&jobReq); err != nil {
+			status, code := api.BodyErrorStatus(err)
+			api.WriteError(w, req, status, code, "invalid request body: "+err.Error())
+			return
+		}
+
+		names := service.CleanNames(jobReq.Names)
+		if len(names) == 0 {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, "missing names")
+			return
+		}
+		if len(names) > maxNames {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest,
+				fmt.Sprintf("too many names: maximum job size is %d", maxNames))
+			return
+		}
+
+		style, err := service.ParseTranslationStyle(jobReq.Style)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		opts, err := pokemonOptions(req)
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		// the results may be read later selecting other fields, so the job translates every description
+		job, err := submit(req.Context(), names, style, service.PokemonOptions{Include: opts.Include})
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		w.Header().Set("Location", "/api/jobs/"+job.ID)
+		api.WriteData(w, req, jobMapper(job, opts), http.StatusAccepted)
+	}
+}
+
+// GetJob serves GET /api/jobs/{id}, with the results of the names done so far.
+func GetJob(getJob TranslationJobGetter) func(w http.ResponseWriter, req *http.Request) {
+	return jobHandler(getJob)
+}
+
+// CancelJob serves DELETE /api/jobs/{id}. Canceling a finished job is a conflict.
+func CancelJob(cancelJob TranslationJobGetter) func(w http.ResponseWriter, req *http.Request) {
+	return jobHandler(cancelJob)
+}
+
+func jobHandler(do TranslationJobGetter) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		opts, err := pokemonOptions(req)
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		job, err := do(req.Context(), req.PathValue("id"))
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		api.WriteData(w, req, jobMapper(job, opts), http.StatusOK)
+	}
+}
+
+func jobMapper(j service.TranslationJob, opts service.PokemonOptions) Job {
+	job := Job{
+		ID:        j.ID,
+		Status:    string(j.Status),
+		Style:     string(j.Style),
+		Progress:  JobProgress{Done: len(j.Results), Total: len(j.Names)},
+		CreatedAt: j.CreatedAt,
+		Results:   make([]BatchItem, 0, len(j.Results)),
+	}
+	if !j.FinishedAt.IsZero() {
+		job.FinishedAt = &j.FinishedAt
+	}
+	for _, r := range j.Results {
+		job.Results = append(job.Results, batchItemMapper(r, opts))
+	}
+	return job
+}
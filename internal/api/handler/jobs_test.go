@@ -0,0 +1,175 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitTranslationJob(t *testing.T) {
+	var submittedNames []string
+	var submittedStyle service.TranslationStyle
+	submit := func(ctx context.Context, names []string, style service.TranslationStyle, opts service.PokemonOptions) (service.TranslationJob, error) {
+		if names[0] == "overflow" {
+			return service.TranslationJob{}, service.ErrQueueFull
+		}
+		submittedNames, submittedStyle = names, style
+		return service.TranslationJob{ID: "job1", Status: service.JobQueued, Style: style, Names: names}, nil
+	}
+	submitHandler := handler.SubmitTranslationJob(submit, 3)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedNames  []string
+		expectedStyle  service.TranslationStyle
+	}{
+		{
+			name:           "success",
+			body:           `{"names": ["pikachu", " ", "zubat "], "style": "yoda"}`,
+			expectedStatus: http.StatusAccepted,
+			expectedNames:  []string{"pikachu", "zubat"},
+			expectedStyle:  service.Yoda,
+		},
+		{
+			name:           "default style",
+			body:           `{"names": ["pikachu"]}`,
+			expectedStatus: http.StatusAccepted,
+			expectedNames:  []string{"pikachu"},
+			expectedStyle:  service.Auto,
+		},
+		{name: "invalid body", body: `{"names": `, expectedStatus: http.StatusBadRequest},
+		{name: "missing names", body: `{"names": []}`, expectedStatus: http.StatusBadRequest},
+		{name: "too many names", body: `{"names": ["a", "b", "c", "d"]}`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid style", body: `{"names": ["a"], "style": "klingon"}`, expectedStatus: http.StatusBadRequest},
+		{name: "queue full", body: `{"names": ["overflow"]}`, expectedStatus: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			submitHandler(res, httptest.NewRequest("POST", "/api/jobs/translations", strings.NewReader(tt.body)))
+
+			require.Equal(t, tt.expectedStatus, res.Code)
+			if tt.expectedStatus != http.StatusAccepted {
+				return
+			}
+
+			assert.Equal(t, "/api/jobs/job1", res.Header().Get("Location"))
+			assert.Equal(t, tt.expectedNames, submittedNames)
+			assert.Equal(t, tt.expectedStyle, submittedStyle)
+
+			var envelope struct {
+				Data handler.Job `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope))
+			assert.Equal(t, "queued", envelope.Data.Status)
+			assert.Equal(t, handler.JobProgress{Done: 0, Total: len(tt.expectedNames)}, envelope.Data.Progress)
+		})
+	}
+}
+
+func TestGetAndCancelJob(t *testing.T) {
+	finishedAt := time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)
+	jobs := map[string]service.TranslationJob{
+		"running": {
+			ID:     "running",
+			Status: service.JobRunning,
+			Style:  service.Auto,
+			Names:  []string{"pikachu", "missingno", "zubat"},
+			Results: []service.PokemonResult{
+				{Name: "pikachu", Pokemon: model.Pokemon{Name: "pikachu", Description: "translated"}},
+				{Name: "missingno", Err: service.ErrNotFound},
+			},
+		},
+		"done": {ID: "done", Status: service.JobCompleted, Style: service.Yoda, FinishedAt: finishedAt},
+	}
+	getJob := func(ctx context.Context, id string) (service.TranslationJob, error) {
+		j, ok := jobs[id]
+		if !ok {
+			return service.TranslationJob{}, service.ErrJobNotFound
+		}
+		return j, nil
+	}
+	cancelJob := func(ctx context.Context, id string) (service.TranslationJob, error) {
+		j, err := getJob(ctx, id)
+		if err == nil && j.Finished() {
+			return j, service.ErrJobFinished
+		}
+		j.Status = service.JobCanceled
+		return j, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/jobs/{id}", handler.GetJob(getJob))
+	mux.HandleFunc("DELETE /api/jobs/{id}", handler.CancelJob(cancelJob))
+
+	tests := []struct {
+		name           string
+		method         string
+		id             string
+		expectedStatus int
+		expectedJob    string
+	}{
+		{
+			name:           "running job",
+			method:         "GET",
+			id:             "running",
+			expectedStatus: http.StatusOK,
+			expectedJob: `{
+				"id": "running", "status": "running", "style": "auto",
+				"progress": {"done": 2, "total": 3},
+				"createdAt": "0001-01-01T00:00:00Z",
+				"results": [
+					{"name": "pikachu", "pokemon": {"id": 0, "name": "pikachu", "description": "translated", "habitat": "", "isLegendary": null}},
+					{"name": "missingno", "error": {"code": "NOT_FOUND", "message": "pokemon not found"}}
+				]
+			}`,
+		},
+		{
+			name:           "finished job",
+			method:         "GET",
+			id:             "done",
+			expectedStatus: http.StatusOK,
+			expectedJob: `{
+				"id": "done", "status": "completed", "style": "yoda",
+				"progress": {"done": 0, "total": 0},
+				"createdAt": "0001-01-01T00:00:00Z", "finishedAt": "2024-05-04T10:00:00Z",
+				"results": []
+			}`,
+		},
+		{name: "unknown job", method: "GET", id: "unknown", expectedStatus: http.StatusNotFound},
+		{name: "cancel", method: "DELETE", id: "running", expectedStatus: http.StatusOK},
+		{name: "cancel finished job", method: "DELETE", id: "done", expectedStatus: http.StatusConflict},
+		{name: "cancel unknown job", method: "DELETE", id: "unknown", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			mux.ServeHTTP(res, httptest.NewRequest(tt.method, "/api/jobs/"+tt.id, nil))
+
+			require.Equal(t, tt.expectedStatus, res.Code)
+			if tt.expectedJob == "" {
+				return
+			}
+
+			var envelope struct {
+				Data json.RawMessage `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope))
+			assert.JSONEq(t, tt.expectedJob, string(envelope.Data))
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+type PokemonLister func(ctx context.Context, limit, offset int) (model.NamePage, error)
+
+var errInvalidCursor = errors.New("invalid cursor parameter")
+
+// ListPokemon serves GET /api/pokemon?limit=&cursor=&expand=.
+// Without expand each item only has its name, with expand it is hydrated through getBatch.
+func ListPokemon(listPokemon PokemonLister, getBatch PokemonBatchGetter) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		expand, err := parseBoolParam(req.URL.Query(), "expand")
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		writePokemonPage(w, req, listPokemon, getBatch, expand)
+	}
+}
+
+// writePokemonPage writes the page of pokemon returned by list for the limit and cursor of the request,
+// hydrating the items through getBatch when expand is set.
+func writePokemonPage(
+	w http.ResponseWriter,
+	req *http.Request,
+	list PokemonLister,
+	getBatch PokemonBatchGetter,
+	expand bool,
+) {
+	limit, offset, err := parsePageRequest(req.URL.Query())
+	if err != nil {
+		api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	opts, err := pokemonOptions(req)
+	if err != nil {
+		api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	page, err := list(req.Context(), limit, offset)
+	if err != nil {
+		handleError(w, req, err)
+		return
+	}
+
+	items := make([]BatchItem, 0, len(page.Names))
+	if expand {
+		for _, r := range getBatch(req.Context(), page.Names, opts) {
+			items = append(items, batchItemMapper(r, opts))
+		}
+	} else {
+		for _, name := range page.Names {
+			items = append(items, BatchItem{Name: name})
+		}
+	}
+
+	api.WriteDataPage(w, req, items, pagination(page), http.StatusOK)
+}
+
+func parsePageRequest(query url.Values) (limit int, offset int, err error) {
+	if cursor := query.Get("cursor"); cursor != "" {
+		limit, offset, err = decodeCursor(cursor)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if l := query.Get("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			return 0, 0, fmt.Errorf("invalid limit parameter: %q", l)
+		}
+	}
+
+	return limit, offset, nil
+}
+
+func parseBoolParam(query url.Values, name string) (bool, error) {
+	v := query.Get(name)
+	if v == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s parameter: %q", name, v)
+	}
+
+	return b, nil
+}
+
+func pagination(page model.NamePage) *api.Pagination {
+	p := &api.Pagination{Total: page.Total}
+	if next := page.Offset + page.Limit; next < page.Total {
+		p.NextCursor = encodeCursor(page.Limit, next)
+	}
+	if page.Offset > 0 {
+		p.PrevCursor = encodeCursor(page.Limit, max(page.Offset-page.Limit, 0))
+	}
+	return p
+}
+
+func encodeCursor(limit, offset int) string {
+	v := url.Values{}
+	v.Set("limit", strconv.Itoa(limit))
+	v.Set("offset", strconv.Itoa(offset))
+	return base64.RawURLEncoding.EncodeToString([]byte(v.Encode()))
+}
+
+func decodeCursor(cursor string) (limit int, offset int, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, errInvalidCursor
+	}
+
+	v, err := url.ParseQuery(string(raw))
+	if err != nil {
+		return 0, 0, errInvalidCursor
+	}
+
+	limit, err = strconv.Atoi(v.Get("limit"))
+	if err != nil || limit < 1 {
+		return 0, 0, errInvalidCursor
+	}
+	offset, err = strconv.Atoi(v.Get("offset"))
+	if err != nil || offset < 0 {
+		return 0, 0, errInvalidCursor
+	}
+
+	return limit, offset, nil
+}
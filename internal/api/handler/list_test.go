@@ -0,0 +1,93 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type listEnvelope struct {
+	Data  []handler.BatchItem `json:"data"`
+	Meta  *api.Meta           `json:"meta"`
+	Error *api.Error          `json:"error"`
+}
+
+func TestListPokemon(t *testing.T) {
+	names := []string{"bulbasaur", "ivysaur", "venusaur", "charmander", "charmeleon"}
+	listPokemon := func(ctx context.Context, limit, offset int) (model.NamePage, error) {
+		if limit == 0 {
+			limit = 2
+		}
+		end := min(offset+limit, len(names))
+		return model.NamePage{Names: names[offset:end], Offset: offset, Limit: limit, Total: len(names)}, nil
+	}
+	getBatch := func(ctx context.Context, names []string, opts service.PokemonOptions) []service.PokemonResult {
+		results := make([]service.PokemonResult, 0, len(names))
+		for _, n := range names {
+			results = append(results, service.PokemonResult{Name: n, Pokemon: model.Pokemon{Name: n, Habitat: "grassland"}})
+		}
+		return results
+	}
+	listHandler := handler.ListPokemon(listPokemon, getBatch)
+
+	get := func(t *testing.T, url string) (int, listEnvelope) {
+		res := httptest.NewRecorder()
+		listHandler(res, httptest.NewRequest("GET", url, nil))
+
+		var envelope listEnvelope
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+		return res.Code, envelope
+	}
+
+	t.Run("GET /api/pokemon follows cursors", func(t *testing.T) {
+		status, first := get(t, "/api/pokemon")
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []handler.BatchItem{{Name: "bulbasaur"}, {Name: "ivysaur"}}, first.Data)
+		assert.Equal(t, 5, first.Meta.Pagination.Total)
+		assert.Empty(t, first.Meta.Pagination.PrevCursor)
+		require.NotEmpty(t, first.Meta.Pagination.NextCursor)
+
+		status, second := get(t, "/api/pokemon?cursor="+first.Meta.Pagination.NextCursor)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []handler.BatchItem{{Name: "venusaur"}, {Name: "charmander"}}, second.Data)
+		require.NotEmpty(t, second.Meta.Pagination.PrevCursor)
+
+		status, back := get(t, "/api/pokemon?cursor="+second.Meta.Pagination.PrevCursor)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, first.Data, back.Data)
+
+		status, last := get(t, "/api/pokemon?cursor="+second.Meta.Pagination.NextCursor)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []handler.BatchItem{{Name: "charmeleon"}}, last.Data)
+		assert.Empty(t, last.Meta.Pagination.NextCursor)
+	})
+
+	t.Run("GET /api/pokemon?expand=true hydrates items", func(t *testing.T) {
+		status, page := get(t, "/api/pokemon?limit=1&expand=true")
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, page.Data, 1)
+		require.NotNil(t, page.Data[0].Pokemon)
+		assert.Equal(t, "grassland", page.Data[0].Pokemon.Habitat)
+	})
+
+	for _, url := range []string{
+		"/api/pokemon?cursor=not-a-cursor",
+		"/api/pokemon?limit=-1",
+		"/api/pokemon?expand=perhaps",
+	} {
+		t.Run("GET "+url+" bad request", func(t *testing.T) {
+			status, envelope := get(t, url)
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.NotNil(t, envelope.Error)
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/fprojetto/pokedex-api/internal/api"
 	"github.com/fprojetto/pokedex-api/internal/model"
@@ -11,14 +18,95 @@ import (
 )
 
 type Pokemon struct {
+	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Habitat     string `json:"habitat"`
 	IsLegendary *bool  `json:"isLegendary"`
+
+	Types       []string  `json:"types,omitempty"`
+	Stats       []Stat    `json:"stats,omitempty"`
+	Abilities   []Ability `json:"abilities,omitempty"`
+	Height      int       `json:"height,omitempty"`
+	Weight      int       `json:"weight,omitempty"`
+	Unavailable []string  `json:"unavailable,omitempty"`
+
+	// fields are the fields selected with ?fields=, nil meaning all of them.
+	fields []string
+}
+
+// pokemonFields are the names of the fields that can be selected with ?fields=.
+var pokemonFields = jsonFieldNames(reflect.TypeFor[Pokemon]())
+
+// MarshalJSON only writes the selected fields. unavailable is always written when set,
+// as it tells why some of them may be empty.
+func (p Pokemon) MarshalJSON() ([]byte, error) {
+	type plain Pokemon
+	if p.fields == nil {
+		return json.Marshal(plain(p))
+	}
+
+	v := reflect.ValueOf(p)
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i := range v.NumField() {
+		name, omitEmpty := jsonField(v.Type().Field(i))
+		if name == "" || (name != "unavailable" && !slices.Contains(p.fields, name)) {
+			continue
+		}
+		value := v.Field(i)
+		if omitEmpty && (value.IsZero() || value.Kind() == reflect.Slice && value.Len() == 0) {
+			continue
+		}
+
+		raw, err := json.Marshal(value.Interface())
+		if err != nil {
+			return nil, err
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(strconv.Quote(name))
+		buf.WriteByte(':')
+		buf.Write(raw)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
 }
 
-type PokemonGetter func(ctx context.Context, name string) (model.Pokemon, error)
-type PokemonGetterTranslator func(ctx context.Context, name string) (model.Pokemon, error)
+// jsonField returns the name in JSON of an exported struct field and whether it is omitted when empty.
+func jsonField(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if !f.IsExported() || tag == "" || tag == "-" {
+		return "", false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, opts == "omitempty"
+}
+
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		if name, _ := jsonField(t.Field(i)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+type Stat struct {
+	Name     string `json:"name"`
+	BaseStat int    `json:"baseStat"`
+}
+
+type Ability struct {
+	Name     string `json:"name"`
+	IsHidden bool   `json:"isHidden"`
+}
+
+type PokemonGetter func(ctx context.Context, name string, opts service.PokemonOptions) (model.Pokemon, error)
+type PokemonGetterTranslator func(ctx context.Context, name string, opts service.PokemonOptions) (model.Pokemon, error)
 
 func GetPokemon(getPokemon PokemonGetter) func(w http.ResponseWriter, req *http.Request) {
 	return func(w http.ResponseWriter, req *http.Request) {
@@ -27,15 +115,21 @@ func GetPokemon(getPokemon PokemonGetter) func(w http.ResponseWriter, req *http.
 			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, "missing name parameter")
 			return
 		}
-		p, err := getPokemon(req.Context(), name)
+		opts, err := pokemonOptions(req)
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		p, err := getPokemon(req.Context(), name, opts)
 		if err != nil {
 			handleError(w, req, err)
 			return
 		}
 
-		pokemon := mapper(p)
+		pokemon := mapper(p, opts)
 
-		api.WriteJSON(w, req, pokemon, http.StatusOK)
+		api.WriteData(w, req, pokemon, http.StatusOK)
 	}
 }
 
@@ -49,32 +143,138 @@ func GetPokemonTranslated(
 			return
 		}
 
-		p, err := getPokemonTranslated(req.Context(), name)
+		opts, err := pokemonOptions(req)
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+
+		p, err := getPokemonTranslated(req.Context(), name, opts)
 		if err != nil {
 			handleError(w, req, err)
 			return
 		}
 
-		pokemon := mapper(p)
+		pokemon := mapper(p, opts)
 
-		api.WriteJSON(w, req, pokemon, http.StatusOK)
+		api.WriteData(w, req, pokemon, http.StatusOK)
 	}
 }
 
-func mapper(p model.Pokemon) Pokemon {
-	return Pokemon{
+// mapper maps p to the response payload, restricted to the fields selected by opts.
+func mapper(p model.Pokemon, opts service.PokemonOptions) Pokemon {
+	pokemon := Pokemon{
+		ID:          p.ID,
 		Name:        p.Name,
 		Description: p.Description,
 		Habitat:     p.Habitat,
 		IsLegendary: p.IsLegendary,
+		Types:       p.Types,
+		Height:      p.Height,
+		Weight:      p.Weight,
+		Unavailable: p.Unavailable,
+		fields:      opts.Fields,
 	}
+	for _, s := range p.Stats {
+		pokemon.Stats = append(pokemon.Stats, Stat{Name: s.Name, BaseStat: s.BaseStat})
+	}
+	for _, a := range p.Abilities {
+		pokemon.Abilities = append(pokemon.Abilities, Ability{Name: a.Name, IsHidden: a.IsHidden})
+	}
+	return pokemon
+}
+
+// pokemonOptions returns the options with the optional sections selected with ?include= and the fields
+// selected with ?fields=. Selecting a field of an optional section also includes the section.
+func pokemonOptions(req *http.Request) (service.PokemonOptions, error) {
+	query := req.URL.Query()
+
+	include, err := service.ParseInclude(query.Get("include"))
+	if err != nil {
+		return service.PokemonOptions{}, err
+	}
+
+	fields, err := parseFields(query.Get("fields"))
+	if err != nil {
+		return service.PokemonOptions{}, err
+	}
+
+	if fields != nil {
+		include.Types = include.Types || slices.Contains(fields, "types")
+		include.Abilities = include.Abilities || slices.Contains(fields, "abilities")
+		include.Stats = include.Stats || slices.ContainsFunc(fields, func(f string) bool {
+			return f == "stats" || f == "height" || f == "weight"
+		})
+	}
+
+	return service.PokemonOptions{Include: include, Fields: fields}, nil
+}
+
+// parseFields parses a comma separated list of pokemon fields, e.g. "name,description". Empty means all fields.
+func parseFields(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if !slices.Contains(pokemonFields, f) {
+			return nil, fmt.Errorf("unknown field %q, allowed fields are: %s", f, strings.Join(pokemonFields, ", "))
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// NotFoundDetails lists the "did you mean" names returned along with a NOT_FOUND error.
+type NotFoundDetails struct {
+	Suggestions []string `json:"suggestions"`
 }
 
 func handleError(w http.ResponseWriter, req *http.Request, err error) {
+	status, code := ErrorStatus(err)
+	api.WriteErrorDetails(w, req, status, code, err.Error(), errorDetails(err))
+}
+
+func errorDetails(err error) any {
+	var notFound *service.NotFoundError
+	if errors.As(err, &notFound) {
+		return NotFoundDetails{Suggestions: notFound.Suggestions}
+	}
+	return nil
+}
+
+// ErrorStatus maps the errors of the services to an http status and an error code of the api.
+func ErrorStatus(err error) (int, string) {
 	switch {
-	case errors.Is(err, service.ErrNotFound):
-		api.WriteError(w, req, http.StatusNotFound, api.ErrCodeNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidName),
+		errors.Is(err, service.ErrInvalidSpriteVariant),
+		errors.Is(err, service.ErrInvalidType),
+		errors.Is(err, service.ErrInvalidHabitat),
+		errors.Is(err, service.ErrInvalidStyle),
+		errors.Is(err, service.ErrInvalidWebhook),
+		errors.Is(err, service.ErrInvalidBatch):
+		return http.StatusBadRequest, api.ErrCodeBadRequest
+	case errors.Is(err, service.ErrNotFound),
+		errors.Is(err, service.ErrSpriteNotFound),
+		errors.Is(err, service.ErrTypeNotFound),
+		errors.Is(err, service.ErrHabitatNotFound),
+		errors.Is(err, service.ErrNoPokemonMatch),
+		errors.Is(err, service.ErrJobNotFound),
+		errors.Is(err, service.ErrWebhookNotFound):
+		return http.StatusNotFound, api.ErrCodeNotFound
+	case errors.Is(err, service.ErrJobFinished):
+		return http.StatusConflict, api.ErrCodeConflict
+	case errors.Is(err, service.ErrQueueFull),
+		errors.Is(err, service.ErrTooManyWebhooks):
+		return http.StatusTooManyRequests, api.ErrCodeTooManyRequests
+	case errors.Is(err, service.ErrJobsClosed):
+		return http.StatusServiceUnavailable, api.ErrCodeUnavailable
 	default:
-		api.WriteError(w, req, http.StatusInternalServerError, api.ErrCodeInternal, err.Error())
+		return http.StatusInternalServerError, api.ErrCodeInternal
 	}
 }
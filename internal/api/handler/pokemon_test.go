@@ -22,12 +22,12 @@ type pokemonServiceMock struct {
 	mock.Mock
 }
 
-func (m *pokemonServiceMock) GetPokemon(ctx context.Context, name string) (model.Pokemon, error) {
+func (m *pokemonServiceMock) GetPokemon(ctx context.Context, name string, opts service.PokemonOptions) (model.Pokemon, error) {
 	args := m.Called(ctx, name)
 	return args.Get(0).(model.Pokemon), args.Error(1)
 }
 
-func (m *pokemonServiceMock) GetPokemonTranslated(ctx context.Context, name string) (model.Pokemon, error) {
+func (m *pokemonServiceMock) GetPokemonTranslated(ctx context.Context, name string, opts service.PokemonOptions) (model.Pokemon, error) {
 	args := m.Called(ctx, name)
 	return args.Get(0).(model.Pokemon), args.Error(1)
 }
@@ -45,6 +45,7 @@ func TestGetPokemon(t *testing.T) {
 			name:        "GET /api/pokemon/{name} success",
 			pokemonName: "mewtwo",
 			mockReturnPokemon: model.Pokemon{
+				ID:          150,
 				Name:        "mewtwo",
 				Description: "It was created by a scientist after years of horrific gene splicing and DNA engineering experiments.",
 				Habitat:     "rare",
@@ -59,6 +60,7 @@ func TestGetPokemon(t *testing.T) {
 				err := json.Unmarshal(body, &envelope)
 				require.NoError(t, err, "failed to unmarshal response")
 
+				assert.Equal(t, expectedPokemon.ID, envelope.Data.ID)
 				assert.Equal(t, expectedPokemon.Name, envelope.Data.Name)
 				assert.Equal(t, expectedPokemon.Description, envelope.Data.Description)
 				assert.Equal(t, expectedPokemon.Habitat, envelope.Data.Habitat)
@@ -78,6 +80,20 @@ func TestGetPokemon(t *testing.T) {
 				assert.NotNil(t, envelope.Error)
 			},
 		},
+		{
+			name:               "GET /api/pokemon/{name} invalid name",
+			pokemonName:        "mew*two",
+			mockReturnPokemon:  model.Pokemon{},
+			mockReturnError:    service.ErrInvalidName,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBodyAssertion: func(t *testing.T, body []byte, expectedPokemon model.Pokemon) {
+				var envelope api.Envelope
+				err := json.Unmarshal(body, &envelope)
+				require.NoError(t, err, "failed to unmarshal response")
+				require.NotNil(t, envelope.Error)
+				assert.Equal(t, api.ErrCodeBadRequest, envelope.Error.Code)
+			},
+		},
 		{
 			name:               "GET /api/pokemon/{name} not found",
 			pokemonName:        "pikachu",
@@ -197,3 +213,106 @@ func TestGetPokemonTranslated(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPokemonInclude(t *testing.T) {
+	getPokemon := func(ctx context.Context, name string, opts service.PokemonOptions) (model.Pokemon, error) {
+		p := model.Pokemon{Name: name, IsLegendary: client.BoolPtr(false)}
+		if opts.Include.Stats {
+			p.Stats = []model.Stat{{Name: "hp", BaseStat: 35}}
+			p.Height = 4
+		}
+		return p, nil
+	}
+
+	t.Run("GET /api/pokemon/{name}?include=stats", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/pokemon/pikachu?include=stats", nil)
+		req.SetPathValue("name", "pikachu")
+		res := httptest.NewRecorder()
+		handler.GetPokemon(getPokemon)(res, req)
+
+		require.Equal(t, http.StatusOK, res.Code)
+
+		var envelope struct {
+			Data handler.Pokemon `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+		assert.Equal(t, []handler.Stat{{Name: "hp", BaseStat: 35}}, envelope.Data.Stats)
+		assert.Equal(t, 4, envelope.Data.Height)
+		assert.Empty(t, envelope.Data.Types)
+	})
+
+	t.Run("GET /api/pokemon/{name}?include=moves bad request", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/pokemon/pikachu?include=moves", nil)
+		req.SetPathValue("name", "pikachu")
+		res := httptest.NewRecorder()
+		handler.GetPokemon(getPokemon)(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestGetPokemonFields(t *testing.T) {
+	getPokemon := func(ctx context.Context, name string, opts service.PokemonOptions) (model.Pokemon, error) {
+		p := model.Pokemon{
+			ID:          25,
+			Name:        name,
+			Description: "A mouse.",
+			Habitat:     "forest",
+			IsLegendary: client.BoolPtr(false),
+		}
+		if opts.Include.Types {
+			p.Types = []string{"electric"}
+		}
+		return p, nil
+	}
+	getHandler := handler.GetPokemon(getPokemon)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedData   string
+	}{
+		{
+			name:           "only the selected fields",
+			query:          "?fields=name,description",
+			expectedStatus: http.StatusOK,
+			expectedData:   `{"name":"pikachu","description":"A mouse."}`,
+		},
+		{
+			name:           "selected optional sections are included",
+			query:          "?fields=name,types",
+			expectedStatus: http.StatusOK,
+			expectedData:   `{"name":"pikachu","types":["electric"]}`,
+		},
+		{
+			name:           "unknown field",
+			query:          "?fields=name,color",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/pokemon/pikachu"+tt.query, nil)
+			req.SetPathValue("name", "pikachu")
+			res := httptest.NewRecorder()
+
+			getHandler(res, req)
+
+			require.Equal(t, tt.expectedStatus, res.Code)
+
+			var envelope struct {
+				Data  json.RawMessage `json:"data"`
+				Error *api.Error      `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+			if tt.expectedStatus != http.StatusOK {
+				require.NotNil(t, envelope.Error)
+				assert.Contains(t, envelope.Error.Message, "allowed fields are: id, name, description")
+				return
+			}
+			assert.JSONEq(t, tt.expectedData, string(envelope.Data))
+		})
+	}
+}
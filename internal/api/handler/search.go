@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type PokemonSearcher func(ctx context.Context, query string, limit int) []service.Match
+
+type SearchResult struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+// SearchPokemon serves GET /api/pokemon/search?q=&limit=, returning at most maxResults names ranked by score.
+func SearchPokemon(search PokemonSearcher, maxResults int) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+
+		q := strings.TrimSpace(query.Get("q"))
+		if q == "" {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, "missing q parameter")
+			return
+		}
+
+		limit := maxResults
+		if l := query.Get("limit"); l != "" {
+			n, err := strconv.Atoi(l)
+			if err != nil || n < 1 {
+				api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, fmt.Sprintf("invalid limit parameter: %q", l))
+				return
+			}
+			limit = min(n, maxResults)
+		}
+
+		matches := search(req.Context(), q, limit)
+
+		results := make([]SearchResult, 0, len(matches))
+		for _, m := range matches {
+			results = append(results, SearchResult{Name: m.Name, Score: m.Score})
+		}
+
+		api.WriteData(w, req, results, http.StatusOK)
+	}
+}
@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchPokemon(t *testing.T) {
+	search := func(ctx context.Context, query string, limit int) []service.Match {
+		matches := []service.Match{{Name: "pikachu", Score: 0.4}, {Name: "pichu", Score: 0.3}}
+		return matches[:min(limit, len(matches))]
+	}
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedResults    []handler.SearchResult
+	}{
+		{
+			name:               "GET /api/pokemon/search success",
+			url:                "/api/pokemon/search?q=pikachoo",
+			expectedStatusCode: http.StatusOK,
+			expectedResults:    []handler.SearchResult{{Name: "pikachu", Score: 0.4}, {Name: "pichu", Score: 0.3}},
+		},
+		{
+			name:               "GET /api/pokemon/search with limit",
+			url:                "/api/pokemon/search?q=pikachoo&limit=1",
+			expectedStatusCode: http.StatusOK,
+			expectedResults:    []handler.SearchResult{{Name: "pikachu", Score: 0.4}},
+		},
+		{
+			name:               "GET /api/pokemon/search limit capped",
+			url:                "/api/pokemon/search?q=pikachoo&limit=100",
+			expectedStatusCode: http.StatusOK,
+			expectedResults:    []handler.SearchResult{{Name: "pikachu", Score: 0.4}, {Name: "pichu", Score: 0.3}},
+		},
+		{
+			name:               "GET /api/pokemon/search missing query",
+			url:                "/api/pokemon/search",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "GET /api/pokemon/search invalid limit",
+			url:                "/api/pokemon/search?q=pika&limit=zero",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchHandler := handler.SearchPokemon(search, 2)
+
+			res := httptest.NewRecorder()
+			searchHandler(res, httptest.NewRequest("GET", tt.url, nil))
+
+			assert.Equal(t, tt.expectedStatusCode, res.Code)
+
+			var envelope struct {
+				Data  []handler.SearchResult `json:"data"`
+				Error *api.Error             `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+
+			if tt.expectedStatusCode != http.StatusOK {
+				assert.NotNil(t, envelope.Error)
+				return
+			}
+			assert.Equal(t, tt.expectedResults, envelope.Data)
+		})
+	}
+}
+
+func TestGetPokemonNotFoundSuggestions(t *testing.T) {
+	getPokemon := func(ctx context.Context, name string, opts service.PokemonOptions) (model.Pokemon, error) {
+		return model.Pokemon{}, &service.NotFoundError{Name: name, Suggestions: []string{"pikachu"}}
+	}
+
+	req := httptest.NewRequest("GET", "/api/pokemon/pikachoo", nil)
+	req.SetPathValue("name", "pikachoo")
+	res := httptest.NewRecorder()
+	handler.GetPokemon(getPokemon)(res, req)
+
+	assert.Equal(t, http.StatusNotFound, res.Code)
+
+	var envelope struct {
+		Error struct {
+			Code    string                  `json:"code"`
+			Details handler.NotFoundDetails `json:"details"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+	assert.Equal(t, api.ErrCodeNotFound, envelope.Error.Code)
+	assert.Equal(t, []string{"pikachu"}, envelope.Error.Details.Suggestions)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+type SpriteGetter func(ctx context.Context, name string, variant string) (model.Sprite, error)
+
+// GetPokemonSprite serves GET /api/pokemon/{name}/sprite?variant=, streaming the image itself.
+// Conditional (If-None-Match, If-Modified-Since) and range requests are supported.
+func GetPokemonSprite(getSprite SpriteGetter) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		name := req.PathValue("name")
+		if name == "" {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, "missing name parameter")
+			return
+		}
+
+		sprite, err := getSprite(req.Context(), name, req.URL.Query().Get("variant"))
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		sum := sha256.Sum256(sprite.Data)
+		w.Header().Set("Content-Type", sprite.ContentType)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+
+		http.ServeContent(w, req, "", sprite.ModTime, bytes.NewReader(sprite.Data))
+	}
+}
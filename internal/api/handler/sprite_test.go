@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPokemonSprite(t *testing.T) {
+	modTime := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+	getSprite := func(ctx context.Context, name string, variant string) (model.Sprite, error) {
+		switch {
+		case variant == "back_female":
+			return model.Sprite{}, service.ErrInvalidSpriteVariant
+		case name == "missingno":
+			return model.Sprite{}, service.ErrNotFound
+		}
+		return model.Sprite{ContentType: "image/png", ModTime: modTime, Data: []byte("\x89PNG")}, nil
+	}
+	spriteHandler := handler.GetPokemonSprite(getSprite)
+
+	serve := func(name, url string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", url, nil)
+		req.SetPathValue("name", name)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		res := httptest.NewRecorder()
+		spriteHandler(res, req)
+		return res
+	}
+
+	res := serve("pikachu", "/api/pokemon/pikachu/sprite", nil)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "image/png", res.Header().Get("Content-Type"))
+	assert.Equal(t, "\x89PNG", res.Body.String())
+	etag := res.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	res = serve("pikachu", "/api/pokemon/pikachu/sprite", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, res.Code)
+	assert.Empty(t, res.Body.String())
+
+	res = serve("pikachu", "/api/pokemon/pikachu/sprite", map[string]string{"If-Modified-Since": modTime.Format(http.TimeFormat)})
+	assert.Equal(t, http.StatusNotModified, res.Code)
+
+	res = serve("pikachu", "/api/pokemon/pikachu/sprite?variant=back_female", nil)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+	assert.Contains(t, res.Header().Get("Content-Type"), "application/json")
+
+	res = serve("missingno", "/api/pokemon/missingno/sprite", nil)
+	assert.Equal(t, http.StatusNotFound, res.Code)
+}
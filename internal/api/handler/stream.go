@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type PokemonTranslationStreamer func(ctx context.Context, names []string, opts service.PokemonOptions) <-chan service.TranslationEvent
+
+// Events of the translation stream.
+const (
+	EventTranslated = "translated"
+	EventFallback   = "fallback"
+	EventError      = "error"
+	EventDone       = "done"
+)
+
+// TranslationStreamItem is the data of the translated, fallback and error events.
+type TranslationStreamItem struct {
+	Name    string     `json:"name"`
+	Pokemon *Pokemon   `json:"pokemon,omitempty"`
+	Error   *api.Error `json:"error,omitempty"`
+	// Fallback tells why the description of a fallback event is not translated.
+	Fallback string `json:"fallback,omitempty"`
+}
+
+// TranslationStreamDone is the data of the done event, sent once every pokemon has been sent.
+type TranslationStreamDone struct {
+	Total int `json:"total"`
+}
+
+// StreamPokemonTranslated serves GET /api/pokemon/translated/stream?names=a,b,c as Server-Sent Events:
+// one event per pokemon as soon as its translation completes, then a done event. Comments are sent every
+// heartbeat so that proxies keep the connection open.
+//
+// The id of an event encodes every pokemon sent so far, so that a client reconnecting with Last-Event-ID
+// (and the same names) only gets the others. Invalid ids are ignored and the stream starts over.
+func StreamPokemonTranslated(
+	stream PokemonTranslationStreamer,
+	maxNames int,
+	heartbeat time.Duration,
+) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		names := service.CleanNames(strings.Split(req.URL.Query().Get("names"), ","))
+		if len(names) == 0 {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, "missing names parameter")
+			return
+		}
+		if len(names) > maxNames {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest,
+				fmt.Sprintf("too many names: maximum stream size is %d", maxNames))
+			return
+		}
+
+		opts, err := pokemonOptions(req)
+		if err != nil {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, err.Error())
+			return
+		}
+		// stops the lookups left when the client goes away
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+
+		sent := decodeEventID(req.Header.Get("Last-Event-ID"), len(names))
+		var pending []int
+		for i := range names {
+			if !sent.has(i) {
+				pending = append(pending, i)
+			}
+		}
+		pendingNames := make([]string, 0, len(pending))
+		for _, i := range pending {
+			pendingNames = append(pendingNames, names[i])
+		}
+
+		h := w.Header()
+		h.Set("Content-Type", "text/event-stream")
+		h.Set("Cache-Control", "no-store")
+		// nginx buffers responses unless told otherwise
+		h.Set("X-Accel-Buffering", "no")
+		rc := http.NewResponseController(w)
+		// the stream outlives the write timeout of the server, the heartbeats detect the clients gone
+		_ = rc.SetWriteDeadline(time.Time{})
+		w.WriteHeader(http.StatusOK)
+
+		if err := rc.Flush(); err != nil {
+			log.Printf("failed to flush event stream: %v", err)
+			return
+		}
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		events := stream(ctx, pendingNames, opts)
+		for {
+			var err error
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_, err = io.WriteString(w, ": heartbeat\n\n")
+			case e, ok := <-events:
+				if !ok {
+					if err := writeEvent(w, "", EventDone, TranslationStreamDone{Total: len(names)}); err == nil {
+						_ = rc.Flush()
+					}
+					return
+				}
+				sent.add(pending[e.Index])
+				name, data := translationEventMapper(e, opts)
+				err = writeEvent(w, sent.String(), name, data)
+			}
+			if err == nil {
+				err = rc.Flush()
+			}
+			if err != nil {
+				// the client is gone
+				return
+			}
+		}
+	}
+}
+
+func translationEventMapper(e service.TranslationEvent, opts service.PokemonOptions) (string, TranslationStreamItem) {
+	if e.Err != nil {
+		_, code := ErrorStatus(e.Err)
+		return EventError, TranslationStreamItem{
+			Name:  e.Name,
+			Error: &api.Error{Code: code, Message: e.Err.Error(), Details: errorDetails(e.Err)},
+		}
+	}
+
+	pokemon := mapper(e.Pokemon, opts)
+	if e.TranslationErr != nil {
+		return EventFallback, TranslationStreamItem{
+			Name:     e.Name,
+			Pokemon:  &pokemon,
+			Fallback: "translation failed, the original description is kept: " + e.TranslationErr.Error(),
+		}
+	}
+	return EventTranslated, TranslationStreamItem{Name: e.Name, Pokemon: &pokemon}
+}
+
+func writeEvent(w io.Writer, id, event string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", event, raw)
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// eventID is the set of the indexes of the names sent, as a bitmap.
+type eventID []byte
+
+func decodeEventID(id string, size int) eventID {
+	bits, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil || len(bits) != (size+7)/8 {
+		return make(eventID, (size+7)/8)
+	}
+	return bits
+}
+
+func (id eventID) has(i int) bool {
+	return id[i/8]&(1<<(i%8)) != 0
+}
+
+func (id eventID) add(i int) {
+	id[i/8] |= 1 << (i % 8)
+}
+
+func (id eventID) String() string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
@@ -0,0 +1,175 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sseEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+func readEvents(t *testing.T, body string) (events []sseEvent, heartbeats int) {
+	var e sseEvent
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if e.event != "" {
+				events = append(events, e)
+			}
+			e = sseEvent{}
+		case strings.HasPrefix(line, ":"):
+			heartbeats++
+		case strings.HasPrefix(line, "id: "):
+			e.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			e.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		default:
+			t.Fatalf("unexpected line %q", line)
+		}
+	}
+	return events, heartbeats
+}
+
+// streamInOrder sends the events of names in order, failing the lookup of missingno and the translation of psyduck.
+func streamInOrder(requested *[]string) handler.PokemonTranslationStreamer {
+	return func(ctx context.Context, names []string, opts service.PokemonOptions) <-chan service.TranslationEvent {
+		*requested = names
+		events := make(chan service.TranslationEvent, len(names))
+		for i, name := range names {
+			e := service.TranslationEvent{Index: i, Name: name, Pokemon: model.Pokemon{Name: name, Description: "translated"}}
+			switch name {
+			case "missingno":
+				e.Err = service.ErrNotFound
+			case "psyduck":
+				e.Pokemon.Description = "original"
+				e.TranslationErr = service.ErrServiceUnavailable
+			}
+			events <- e
+		}
+		close(events)
+		return events
+	}
+}
+
+func TestStreamPokemonTranslated(t *testing.T) {
+	var requested []string
+	streamHandler := handler.StreamPokemonTranslated(streamInOrder(&requested), 3, time.Minute)
+
+	t.Run("one event per pokemon then done", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		streamHandler(res, httptest.NewRequest("GET", "/api/pokemon/translated/stream?names=pikachu,missingno,psyduck", nil))
+
+		require.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "text/event-stream", res.Header().Get("Content-Type"))
+		assert.True(t, res.Flushed)
+
+		events, _ := readEvents(t, res.Body.String())
+		require.Len(t, events, 4)
+
+		assert.Equal(t, handler.EventTranslated, events[0].event)
+		assert.JSONEq(t, `{"name":"pikachu","pokemon":{"id":0,"name":"pikachu","description":"translated","habitat":"","isLegendary":null}}`, events[0].data)
+
+		assert.Equal(t, handler.EventError, events[1].event)
+		var item handler.TranslationStreamItem
+		require.NoError(t, json.Unmarshal([]byte(events[1].data), &item))
+		assert.Equal(t, "NOT_FOUND", item.Error.Code)
+
+		assert.Equal(t, handler.EventFallback, events[2].event)
+		require.NoError(t, json.Unmarshal([]byte(events[2].data), &item))
+		assert.Equal(t, "original", item.Pokemon.Description)
+		assert.Contains(t, item.Fallback, "service unavailable")
+
+		assert.Equal(t, handler.EventDone, events[3].event)
+		assert.JSONEq(t, `{"total":3}`, events[3].data)
+		assert.Empty(t, events[3].id)
+	})
+
+	t.Run("resume from Last-Event-ID", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		streamHandler(res, httptest.NewRequest("GET", "/api/pokemon/translated/stream?names=pikachu,missingno,psyduck", nil))
+		events, _ := readEvents(t, res.Body.String())
+
+		req := httptest.NewRequest("GET", "/api/pokemon/translated/stream?names=pikachu,missingno,psyduck", nil)
+		req.Header.Set("Last-Event-ID", events[0].id)
+		res = httptest.NewRecorder()
+		streamHandler(res, req)
+
+		resumed, _ := readEvents(t, res.Body.String())
+		assert.Equal(t, []string{"missingno", "psyduck"}, requested)
+		require.Len(t, resumed, 3)
+		// ids keep counting the pokemon sent before the reconnection
+		assert.Equal(t, events[2].id, resumed[1].id)
+	})
+
+	t.Run("invalid Last-Event-ID starts over", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/pokemon/translated/stream?names=pikachu,psyduck", nil)
+		req.Header.Set("Last-Event-ID", "not-an-id!")
+		res := httptest.NewRecorder()
+		streamHandler(res, req)
+
+		assert.Equal(t, []string{"pikachu", "psyduck"}, requested)
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		for _, query := range []string{"", "?names=a,b,c,d", "?names=a&include=moves"} {
+			res := httptest.NewRecorder()
+			streamHandler(res, httptest.NewRequest("GET", "/api/pokemon/translated/stream"+query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, res.Code, query)
+		}
+	})
+}
+
+func TestStreamPokemonTranslatedHeartbeatAndDisconnect(t *testing.T) {
+	canceled := make(chan struct{})
+	slowStream := func(ctx context.Context, names []string, opts service.PokemonOptions) <-chan service.TranslationEvent {
+		events := make(chan service.TranslationEvent)
+		go func() {
+			<-ctx.Done()
+			close(canceled)
+			close(events)
+		}()
+		return events
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(handler.StreamPokemonTranslated(slowStream, 10, 10*time.Millisecond)))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, "GET", srv.URL+"?names=pikachu", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": heartbeat\n", line)
+
+	// the lookups are stopped when the client goes away
+	cancel()
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("stream not canceled after the client disconnected")
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+// Type lists the non neutral damage multipliers of a type, when attacking and when defending.
+type Type struct {
+	Name    string             `json:"name"`
+	Attack  map[string]float64 `json:"attack"`
+	Defense map[string]float64 `json:"defense"`
+}
+
+type DefenseMatchup struct {
+	Name        string           `json:"name"`
+	Types       []string         `json:"types"`
+	Weaknesses  []TypeMultiplier `json:"weaknesses"`
+	Resistances []TypeMultiplier `json:"resistances"`
+	Immunities  []string         `json:"immunities"`
+}
+
+type TypeMultiplier struct {
+	Type       string  `json:"type"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+type TypeGetter func(ctx context.Context, name string) (model.TypeRelations, error)
+type WeaknessGetter func(ctx context.Context, name string) (model.DefenseMatchup, error)
+
+func GetType(getType TypeGetter) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		name := req.PathValue("type")
+		if name == "" {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, "missing type parameter")
+			return
+		}
+
+		t, err := getType(req.Context(), name)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		api.WriteData(w, req, Type{
+			Name:    t.Name,
+			Attack:  service.AttackMultipliers(t),
+			Defense: service.DefenseMultipliers(t),
+		}, http.StatusOK)
+	}
+}
+
+func GetPokemonWeaknesses(getWeaknesses WeaknessGetter) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		name := req.PathValue("name")
+		if name == "" {
+			api.WriteError(w, req, http.StatusBadRequest, api.ErrCodeBadRequest, "missing name parameter")
+			return
+		}
+
+		m, err := getWeaknesses(req.Context(), name)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		api.WriteData(w, req, DefenseMatchup{
+			Name:        m.Name,
+			Types:       m.Types,
+			Weaknesses:  typeMultipliersMapper(m.Weaknesses),
+			Resistances: typeMultipliersMapper(m.Resistances),
+			Immunities:  append([]string{}, m.Immunities...),
+		}, http.StatusOK)
+	}
+}
+
+func typeMultipliersMapper(multipliers []model.TypeMultiplier) []TypeMultiplier {
+	mapped := make([]TypeMultiplier, 0, len(multipliers))
+	for _, m := range multipliers {
+		mapped = append(mapped, TypeMultiplier(m))
+	}
+	return mapped
+}
@@ -0,0 +1,84 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetType(t *testing.T) {
+	getType := func(ctx context.Context, name string) (model.TypeRelations, error) {
+		if name != "ground" {
+			return model.TypeRelations{}, service.ErrTypeNotFound
+		}
+		return model.TypeRelations{
+			Name:           "ground",
+			DoubleDamageTo: []string{"fire"},
+			NoDamageTo:     []string{"flying"},
+			NoDamageFrom:   []string{"electric"},
+		}, nil
+	}
+
+	t.Run("GET /api/types/{type} success", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/types/ground", nil)
+		req.SetPathValue("type", "ground")
+		res := httptest.NewRecorder()
+		handler.GetType(getType)(res, req)
+
+		require.Equal(t, http.StatusOK, res.Code)
+
+		var envelope struct {
+			Data handler.Type `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+		assert.Equal(t, handler.Type{
+			Name:    "ground",
+			Attack:  map[string]float64{"fire": 2, "flying": 0},
+			Defense: map[string]float64{"electric": 0},
+		}, envelope.Data)
+	})
+
+	t.Run("GET /api/types/{type} not found", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/types/shadow", nil)
+		req.SetPathValue("type", "shadow")
+		res := httptest.NewRecorder()
+		handler.GetType(getType)(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
+
+func TestGetPokemonWeaknesses(t *testing.T) {
+	getWeaknesses := func(ctx context.Context, name string) (model.DefenseMatchup, error) {
+		return model.DefenseMatchup{
+			Name:        "charizard",
+			Types:       []string{"fire", "flying"},
+			Weaknesses:  []model.TypeMultiplier{{Type: "rock", Multiplier: 4}},
+			Resistances: []model.TypeMultiplier{{Type: "grass", Multiplier: 0.25}},
+			Immunities:  []string{"ground"},
+		}, nil
+	}
+
+	req := httptest.NewRequest("GET", "/api/pokemon/charizard/weaknesses", nil)
+	req.SetPathValue("name", "charizard")
+	res := httptest.NewRecorder()
+	handler.GetPokemonWeaknesses(getWeaknesses)(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code)
+
+	var envelope struct {
+		Data handler.DefenseMatchup `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope), "failed to unmarshal response")
+	assert.Equal(t, []handler.TypeMultiplier{{Type: "rock", Multiplier: 4}}, envelope.Data.Weaknesses)
+	assert.Equal(t, []handler.TypeMultiplier{{Type: "grass", Multiplier: 0.25}}, envelope.Data.Resistances)
+	assert.Equal(t, []string{"ground"}, envelope.Data.Immunities)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/fprojetto/pokedex-api/internal/service"
+)
+
+type WebhookSubscriber func(ctx context.Context, url string, events []string, secret string) (service.WebhookSubscription, error)
+type WebhookLister func(ctx context.Context) []service.WebhookSubscription
+type WebhookUnsubscriber func(ctx context.Context, id string) error
+type DeadLetterLister func(ctx context.Context) []service.DeadLetter
+
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Secret signs the payloads, generated when empty.
+	Secret string `json:"secret"`
+}
+
+type Webhook struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Secret is only returned when the webhook is created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type DeadLetter struct {
+	DeliveryID string    `json:"deliveryId"`
+	WebhookID  string    `json:"webhookId"`
+	URL        string    `json:"url"`
+	EventID    string    `json:"eventId"`
+	Event      string    `json:"event"`
+	Attempts   int       `json:"attempts"`
+	Error      string    `json:"error"`
+	FailedAt   time.Time `json:"failedAt"`
+}
+
+// JobEvent is the data of the job events sent to the webhooks: the job without its results,
+// which are read at URL.
+type JobEvent struct {
+	ID         string      `json:"id"`
+	Status     string      `json:"status"`
+	Style      string      `json:"style"`
+	Progress   JobProgress `json:"progress"`
+	CreatedAt  time.Time   `json:"createdAt"`
+	FinishedAt *time.Time  `json:"finishedAt,omitempty"`
+	URL        string      `json:"url"`
+}
+
+// BatchEvent is the data of the batch events sent to the webhooks: the outcome of a translated batch,
+// without its pokemon.
+type BatchEvent struct {
+	Names []string `json:"names"`
+	// Failed counts the names not found or failed to look up.
+	Failed int `json:"failed"`
+	// Untranslated counts the pokemon left untranslated by the translation budget.
+	Untranslated int `json:"untranslated"`
+}
+
+// CreateWebhook serves POST /api/webhooks.
+func CreateWebhook(subscribe WebhookSubscriber) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var webhookReq WebhookRequest
+		if err := json.NewDecoder(req.Body).Decode(&webhookReq); err != nil {
+			status, code := api.BodyErrorStatus(err)
+			api.WriteError(w, req, status, code, "invalid request body: "+err.Error())
+			return
+		}
+
+		sub, err := subscribe(req.Context(), webhookReq.URL, webhookReq.Events, webhookReq.Secret)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		webhook := webhookMapper(sub)
+		webhook.Secret = sub.Secret
+		w.Header().Set("Location", "/api/webhooks/"+sub.ID)
+		api.WriteData(w, req, webhook, http.StatusCreated)
+	}
+}
+
+// ListWebhooks serves GET /api/webhooks.
+func ListWebhooks(list WebhookLister) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		subs := list(req.Context())
+		webhooks := make([]Webhook, 0, len(subs))
+		for _, sub := range subs {
+			webhooks = append(webhooks, webhookMapper(sub))
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		api.WriteData(w, req, webhooks, http.StatusOK)
+	}
+}
+
+// DeleteWebhook serves DELETE /api/webhooks/{id}.
+func DeleteWebhook(unsubscribe WebhookUnsubscriber) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if err := unsubscribe(req.Context(), req.PathValue("id")); err != nil {
+			handleError(w, req, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ListDeadLetters serves GET /api/admin/webhooks/dead-letters, the deliveries given up, oldest first.
+func ListDeadLetters(list DeadLetterLister) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		deadLetters := list(req.Context())
+		items := make([]DeadLetter, 0, len(deadLetters))
+		for _, dl := range deadLetters {
+			items = append(items, DeadLetter{
+				DeliveryID: dl.Delivery.ID,
+				WebhookID:  dl.Delivery.Subscription.ID,
+				URL:        dl.Delivery.Subscription.URL,
+				EventID:    dl.Delivery.Event.ID,
+				Event:      dl.Delivery.Event.Type,
+				Attempts:   dl.Delivery.Attempt,
+				Error:      dl.Err,
+				FailedAt:   dl.FailedAt,
+			})
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		api.WriteData(w, req, items, http.StatusOK)
+	}
+}
+
+// JobEventMapper maps a finished job to the data of its event.
+func JobEventMapper(j service.TranslationJob) JobEvent {
+	event := JobEvent{
+		ID:        j.ID,
+		Status:    string(j.Status),
+		Style:     string(j.Style),
+		Progress:  JobProgress{Done: len(j.Results), Total: len(j.Names)},
+		CreatedAt: j.CreatedAt,
+		URL:       "/api/jobs/" + j.ID,
+	}
+	if !j.FinishedAt.IsZero() {
+		event.FinishedAt = &j.FinishedAt
+	}
+	return event
+}
+
+// BatchEventMapper maps the results of a translated batch to the data of its event.
+func BatchEventMapper(results []service.PokemonResult) BatchEvent {
+	event := BatchEvent{Names: make([]string, 0, len(results))}
+	for _, r := range results {
+		event.Names = append(event.Names, r.Name)
+		switch {
+		case r.Err != nil:
+			event.Failed++
+		case slices.Contains(r.Pokemon.Unavailable, service.UnavailableTranslation):
+			event.Untranslated++
+		}
+	}
+	return event
+}
+
+func webhookMapper(sub service.WebhookSubscription) Webhook {
+	return Webhook{ID: sub.ID, URL: sub.URL, Events: sub.Events, CreatedAt: sub.CreatedAt}
+}
@@ -0,0 +1,163 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooks(t *testing.T) {
+	createdAt := time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)
+	sub := service.WebhookSubscription{
+		ID:        "hook1",
+		URL:       "https://example.com/hook",
+		Events:    []string{service.EventJobCompleted},
+		Secret:    "s3cret",
+		CreatedAt: createdAt,
+	}
+	subscribe := func(ctx context.Context, url string, events []string, secret string) (service.WebhookSubscription, error) {
+		if url == "/relative" {
+			return service.WebhookSubscription{}, service.ErrInvalidWebhook
+		}
+		return sub, nil
+	}
+	list := func(ctx context.Context) []service.WebhookSubscription {
+		return []service.WebhookSubscription{sub}
+	}
+	unsubscribe := func(ctx context.Context, id string) error {
+		if id != sub.ID {
+			return service.ErrWebhookNotFound
+		}
+		return nil
+	}
+	deadLetters := func(ctx context.Context) []service.DeadLetter {
+		return []service.DeadLetter{{
+			Delivery: service.WebhookDelivery{
+				ID:           "delivery1",
+				Subscription: sub,
+				Event:        service.WebhookEvent{ID: "event1", Type: service.EventJobCompleted},
+				Attempt:      5,
+			},
+			Err:      "unexpected response status 500",
+			FailedAt: createdAt,
+		}}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/webhooks", handler.CreateWebhook(subscribe))
+	mux.HandleFunc("GET /api/webhooks", handler.ListWebhooks(list))
+	mux.HandleFunc("DELETE /api/webhooks/{id}", handler.DeleteWebhook(unsubscribe))
+	mux.HandleFunc("GET /api/admin/webhooks/dead-letters", handler.ListDeadLetters(deadLetters))
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedData   string
+	}{
+		{
+			name:           "create",
+			method:         "POST",
+			path:           "/api/webhooks",
+			body:           `{"url": "https://example.com/hook", "events": ["job.completed"]}`,
+			expectedStatus: http.StatusCreated,
+			expectedData: `{
+				"id": "hook1", "url": "https://example.com/hook", "events": ["job.completed"],
+				"secret": "s3cret", "createdAt": "2024-05-04T10:00:00Z"
+			}`,
+		},
+		{name: "create with invalid body", method: "POST", path: "/api/webhooks", body: `{"url": `, expectedStatus: http.StatusBadRequest},
+		{name: "create invalid webhook", method: "POST", path: "/api/webhooks", body: `{"url": "/relative"}`, expectedStatus: http.StatusBadRequest},
+		{
+			name:           "list without secrets",
+			method:         "GET",
+			path:           "/api/webhooks",
+			expectedStatus: http.StatusOK,
+			expectedData: `[{
+				"id": "hook1", "url": "https://example.com/hook", "events": ["job.completed"],
+				"createdAt": "2024-05-04T10:00:00Z"
+			}]`,
+		},
+		{name: "delete", method: "DELETE", path: "/api/webhooks/hook1", expectedStatus: http.StatusNoContent},
+		{name: "delete unknown webhook", method: "DELETE", path: "/api/webhooks/unknown", expectedStatus: http.StatusNotFound},
+		{
+			name:           "dead letters",
+			method:         "GET",
+			path:           "/api/admin/webhooks/dead-letters",
+			expectedStatus: http.StatusOK,
+			expectedData: `[{
+				"deliveryId": "delivery1", "webhookId": "hook1", "url": "https://example.com/hook",
+				"eventId": "event1", "event": "job.completed", "attempts": 5,
+				"error": "unexpected response status 500", "failedAt": "2024-05-04T10:00:00Z"
+			}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			mux.ServeHTTP(res, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			require.Equal(t, tt.expectedStatus, res.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				assert.Equal(t, "/api/webhooks/hook1", res.Header().Get("Location"))
+			}
+			if tt.expectedData == "" {
+				return
+			}
+
+			var envelope struct {
+				Data json.RawMessage `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &envelope))
+			assert.JSONEq(t, tt.expectedData, string(envelope.Data))
+		})
+	}
+}
+
+func TestJobEventMapper(t *testing.T) {
+	finishedAt := time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)
+	job := service.TranslationJob{
+		ID:         "job1",
+		Status:     service.JobCompleted,
+		Style:      service.Yoda,
+		Names:      []string{"pikachu"},
+		Results:    []service.PokemonResult{{Name: "pikachu"}},
+		FinishedAt: finishedAt,
+	}
+
+	assert.Equal(t, handler.JobEvent{
+		ID:         "job1",
+		Status:     "completed",
+		Style:      "yoda",
+		Progress:   handler.JobProgress{Done: 1, Total: 1},
+		FinishedAt: &finishedAt,
+		URL:        "/api/jobs/job1",
+	}, handler.JobEventMapper(job))
+}
+
+func TestBatchEventMapper(t *testing.T) {
+	results := []service.PokemonResult{
+		{Name: "pikachu", Pokemon: model.Pokemon{Name: "pikachu"}},
+		{Name: "mewtwo", Pokemon: model.Pokemon{Name: "mewtwo", Unavailable: []string{service.UnavailableTranslation}}},
+		{Name: "missingno", Err: service.ErrNotFound},
+	}
+
+	assert.Equal(t, handler.BatchEvent{
+		Names:        []string{"pikachu", "mewtwo", "missingno"},
+		Failed:       1,
+		Untranslated: 1,
+	}, handler.BatchEventMapper(results))
+}
@@ -1,7 +1,7 @@
 package api
 
 import (
-	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 
@@ -16,7 +16,15 @@ type Envelope struct {
 }
 
 type Meta struct {
-	RequestID string `json:"request_id,omitempty"`
+	RequestID  string      `json:"request_id,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination describes where a page sits in a listing. Cursors are opaque to clients.
+type Pagination struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Total      int    `json:"total"`
 }
 
 type Error struct {
@@ -29,39 +37,98 @@ const (
 	ErrCodeInternal   = "INTERNAL_ERROR"
 	ErrCodeNotFound   = "NOT_FOUND"
 	ErrCodeBadRequest = "BAD_REQUEST"
+
+	ErrCodePayloadTooLarge = "PAYLOAD_TOO_LARGE"
+
+	ErrCodeNotAcceptable = "NOT_ACCEPTABLE"
+
+	ErrCodeUnauthorized = "UNAUTHORIZED"
+	ErrCodeForbidden    = "FORBIDDEN"
+
+	ErrCodeConflict        = "CONFLICT"
+	ErrCodeTooManyRequests = "TOO_MANY_REQUESTS"
+	ErrCodeUnavailable     = "SERVICE_UNAVAILABLE"
 )
 
-func WriteJSON(w http.ResponseWriter, r *http.Request, data any, status int) {
+// WriteData sends data in the format negotiated with the client, JSON unless asked otherwise, see WithFormat.
+func WriteData(w http.ResponseWriter, r *http.Request, data any, status int) {
+	WriteDataPage(w, r, data, nil, status)
+}
+
+// WriteDataPage sends a page of a listing along with its pagination metadata.
+// When none of the formats accepted by the client is supported a NOT_ACCEPTABLE error is sent instead.
+func WriteDataPage(w http.ResponseWriter, r *http.Request, data any, page *Pagination, status int) {
+	format, ok := requestFormat(r)
+	if !ok {
+		writeNotAcceptable(w, r)
+		return
+	}
+
 	requestID := server.GetRequestID(r.Context())
 	resp := Envelope{
 		Data: data,
-		Meta: &Meta{RequestID: requestID},
+		Meta: &Meta{RequestID: requestID, Pagination: page},
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	err := json.NewEncoder(w).Encode(resp)
-	if err != nil {
-		log.Printf("failed to write json: %v", err)
+	if err := writeEnvelope(w, format, resp, status); err != nil {
+		log.Printf("failed to write %s: %v", format.Name, err)
 	}
 }
 
 // WriteError sends a structured error response
 func WriteError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	WriteErrorDetails(w, r, status, code, message, nil)
+}
+
+// WriteErrorDetails sends a structured error response with additional details for the client.
+// Errors fall back to JSON rather than to NOT_ACCEPTABLE, so that the client still learns what went wrong.
+func WriteErrorDetails(w http.ResponseWriter, r *http.Request, status int, code, message string, details any) {
+	format, ok := requestFormat(r)
+	if !ok {
+		format = defaultFormat()
+	}
+
 	requestID := server.GetRequestID(r.Context())
 	resp := Envelope{
 		Error: &Error{
 			Code:    code,
 			Message: message,
+			Details: details,
 		},
 		Meta: &Meta{RequestID: requestID},
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	err := json.NewEncoder(w).Encode(resp)
-	if err != nil {
+	if err := writeEnvelope(w, format, resp, status); err != nil {
+		log.Printf("failed to write %s: %v", format.Name, err)
+	}
+}
+
+// BodyErrorStatus maps an error reading a request body to an http status and an error code:
+// bodies over the size limit of the server are PAYLOAD_TOO_LARGE, the others BAD_REQUEST.
+func BodyErrorStatus(err error) (int, string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge
+	}
+	return http.StatusBadRequest, ErrCodeBadRequest
+}
+
+// NotAcceptableDetails lists the media types the client can ask for.
+type NotAcceptableDetails struct {
+	Supported []string `json:"supported"`
+}
+
+func writeNotAcceptable(w http.ResponseWriter, r *http.Request) {
+	resp := Envelope{
+		Error: &Error{
+			Code:    ErrCodeNotAcceptable,
+			Message: "none of the accepted formats is supported",
+			Details: NotAcceptableDetails{Supported: SupportedMediaTypes()},
+		},
+		Meta: &Meta{RequestID: server.GetRequestID(r.Context())},
+	}
+
+	if err := writeEnvelope(w, defaultFormat(), resp, http.StatusNotAcceptable); err != nil {
 		log.Printf("failed to write json: %v", err)
 	}
 }
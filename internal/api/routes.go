@@ -2,14 +2,112 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/fprojetto/pokedex-api/pkg/server"
 )
 
-func NewPokemonRouter(getPokemon http.HandlerFunc, getPokemonTranslated http.HandlerFunc) http.Handler {
+type PokemonHandlers struct {
+	GetPokemon           http.HandlerFunc
+	GetPokemonTranslated http.HandlerFunc
+	StreamTranslated     http.HandlerFunc
+	GetPokemonBatch      http.HandlerFunc
+	ListPokemon          http.HandlerFunc
+	SearchPokemon        http.HandlerFunc
+	GetPokemonEvolutions http.HandlerFunc
+	GetPokemonSprite     http.HandlerFunc
+	GetPokemonWeaknesses http.HandlerFunc
+	GetType              http.HandlerFunc
+	ComparePokemon       http.HandlerFunc
+	ListHabitats         http.HandlerFunc
+	ListHabitatPokemon   http.HandlerFunc
+	GetRandomPokemon     http.HandlerFunc
+	GetDailyPokemon      http.HandlerFunc
+	GraphQL              http.HandlerFunc
+	SubmitTranslationJob http.HandlerFunc
+	GetJob               http.HandlerFunc
+	CancelJob            http.HandlerFunc
+	CreateWebhook        http.HandlerFunc
+	ListWebhooks         http.HandlerFunc
+	DeleteWebhook        http.HandlerFunc
+	ListDeadLetters      http.HandlerFunc
+	ReloadConfig         http.HandlerFunc
+	GetConfigReloads     http.HandlerFunc
+}
+
+// RouterConfig holds the settings of the api routes.
+type RouterConfig struct {
+	// AdminToken is required by the routes under /api/admin/ and /api/webhooks, see RequireToken.
+	AdminToken string
+	// HandlerTimeout bounds the routes, see WithTimeout.
+	HandlerTimeout time.Duration
+	// TranslatedHandlerTimeout bounds the routes translating, or looking up, many pokemon at once.
+	TranslatedHandlerTimeout time.Duration
+}
+
+// NewPokemonRouter routes the api. The translation stream is the only route without a timeout. The format of the
+// routes answering with data is negotiated upfront, see WithFormat: the stream, the sprites and GraphQL have their own.
+func NewPokemonRouter(h PokemonHandlers, cfg RouterConfig) http.Handler {
+	timeout := func(h http.HandlerFunc) http.Handler { return WithTimeout(cfg.HandlerTimeout, h) }
+	translatedTimeout := func(h http.HandlerFunc) http.Handler { return WithTimeout(cfg.TranslatedHandlerTimeout, h) }
+	data := func(h http.HandlerFunc) http.HandlerFunc { return WithFormat(h).ServeHTTP }
+
 	apiMux := http.NewServeMux()
-	apiMux.HandleFunc("GET /api/pokemon/{name}", getPokemon)
-	apiMux.HandleFunc("GET /api/pokemon/translated/{name}", getPokemonTranslated)
+	apiMux.Handle("GET /api/pokemon/{name}", timeout(data(h.GetPokemon)))
+	apiMux.Handle("GET /api/pokemon/translated/{name}", translatedTimeout(data(h.GetPokemonTranslated)))
+	apiMux.HandleFunc("GET /api/pokemon/translated/stream", h.StreamTranslated)
+	apiMux.Handle("GET /api/pokemon", translatedTimeout(data(pokemonCollection(h.ListPokemon, h.GetPokemonBatch))))
+	apiMux.Handle("POST /api/pokemon/batch", translatedTimeout(data(h.GetPokemonBatch)))
+	apiMux.Handle("GET /api/pokemon/search", timeout(data(h.SearchPokemon)))
+	apiMux.Handle("GET /api/pokemon/compare", timeout(data(h.ComparePokemon)))
+	apiMux.Handle("GET /api/pokemon/random", translatedTimeout(data(h.GetRandomPokemon)))
+	apiMux.Handle("GET /api/pokemon/daily", translatedTimeout(data(h.GetDailyPokemon)))
+	apiMux.Handle("GET /api/pokemon/{name}/{resource}", timeout(pokemonResources(map[string]http.HandlerFunc{
+		"evolutions": data(h.GetPokemonEvolutions),
+		"sprite":     h.GetPokemonSprite,
+		"weaknesses": data(h.GetPokemonWeaknesses),
+	})))
+	apiMux.Handle("GET /api/types/{type}", timeout(data(h.GetType)))
+	apiMux.Handle("GET /api/habitats", timeout(data(h.ListHabitats)))
+	apiMux.Handle("GET /api/habitats/{habitat}/pokemon", translatedTimeout(data(h.ListHabitatPokemon)))
+	apiMux.Handle("POST /api/graphql", translatedTimeout(h.GraphQL))
+	apiMux.Handle("POST /api/jobs/translations", timeout(data(h.SubmitTranslationJob)))
+	apiMux.Handle("GET /api/jobs/{id}", timeout(data(h.GetJob)))
+	apiMux.Handle("DELETE /api/jobs/{id}", timeout(data(h.CancelJob)))
+	// the webhooks make the server send requests to any url, so they are managed by the admins
+	apiMux.Handle("POST /api/webhooks", RequireToken(cfg.AdminToken, timeout(data(h.CreateWebhook))))
+	apiMux.Handle("GET /api/webhooks", RequireToken(cfg.AdminToken, timeout(data(h.ListWebhooks))))
+	apiMux.Handle("DELETE /api/webhooks/{id}", RequireToken(cfg.AdminToken, timeout(data(h.DeleteWebhook))))
+
+	adminMux := http.NewServeMux()
+	adminMux.HandleFunc("GET /api/admin/webhooks/dead-letters", h.ListDeadLetters)
+	adminMux.HandleFunc("POST /api/admin/config/reload", h.ReloadConfig)
+	adminMux.HandleFunc("GET /api/admin/config/reloads", h.GetConfigReloads)
+	apiMux.Handle("/api/admin/", RequireToken(cfg.AdminToken, timeout(data(adminMux.ServeHTTP))))
 
 	return server.RequestIDMiddleware(apiMux)
 }
+
+// pokemonCollection routes GET /api/pokemon to the batch lookup when names are given, to the listing otherwise.
+func pokemonCollection(list http.HandlerFunc, batch http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("names") {
+			batch(w, r)
+			return
+		}
+		list(w, r)
+	}
+}
+
+// pokemonResources routes GET /api/pokemon/{name}/{resource} to the handler of the resource.
+// A single pattern is used because "/api/pokemon/{name}/evolutions" would conflict with "/api/pokemon/translated/{name}".
+func pokemonResources(resources map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h, ok := resources[r.PathValue("resource")]
+		if !ok {
+			WriteError(w, r, http.StatusNotFound, ErrCodeNotFound, "resource not found")
+			return
+		}
+		h(w, r)
+	}
+}
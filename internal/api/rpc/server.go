@@ -0,0 +1,196 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/pokedexpb"
+)
+
+// errorDomain is the domain of the ErrorInfo details of the errors, see toStatus.
+const errorDomain = "pokedex-api"
+
+// Services are the service functions of the HTTP API the gRPC server shares.
+type Services struct {
+	GetPokemon                handler.PokemonGetter
+	GetPokemonTranslated      handler.PokemonGetterTranslator
+	GetPokemonBatch           handler.PokemonBatchGetter
+	GetPokemonBatchTranslated handler.PokemonBatchGetter
+	ListPokemon               handler.PokemonLister
+	MaxBatchSize              int
+}
+
+type pokedexServer struct {
+	pokedexpb.UnimplementedPokedexServiceServer
+	services Services
+}
+
+// NewPokedexServer returns the implementation of pokedex.v1.PokedexService.
+func NewPokedexServer(services Services) pokedexpb.PokedexServiceServer {
+	return &pokedexServer{services: services}
+}
+
+func (s *pokedexServer) GetPokemon(ctx context.Context, req *pokedexpb.GetPokemonRequest) (*pokedexpb.Pokemon, error) {
+	return getPokemon(ctx, req, s.services.GetPokemon)
+}
+
+func (s *pokedexServer) GetPokemonTranslated(ctx context.Context, req *pokedexpb.GetPokemonRequest) (*pokedexpb.Pokemon, error) {
+	return getPokemon(ctx, req, s.services.GetPokemonTranslated)
+}
+
+func getPokemon(
+	ctx context.Context,
+	req *pokedexpb.GetPokemonRequest,
+	get func(ctx context.Context, name string, opts service.PokemonOptions) (model.Pokemon, error),
+) (*pokedexpb.Pokemon, error) {
+	inc, err := service.ParseInclude(req.GetInclude()...)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	p, err := get(ctx, req.GetName(), service.PokemonOptions{Include: inc})
+	if err != nil {
+		return nil, toStatus(err).Err()
+	}
+	return pokemonMapper(p), nil
+}
+
+func (s *pokedexServer) BatchGetPokemon(
+	ctx context.Context,
+	req *pokedexpb.BatchGetPokemonRequest,
+) (*pokedexpb.BatchGetPokemonResponse, error) {
+	names, err := service.BatchNames(req.GetNames(), s.services.MaxBatchSize)
+	if err != nil {
+		return nil, toStatus(err).Err()
+	}
+
+	inc, err := service.ParseInclude(req.GetInclude()...)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	getter := s.services.GetPokemonBatch
+	if req.GetTranslated() {
+		getter = s.services.GetPokemonBatchTranslated
+	}
+
+	results := getter(ctx, names, service.PokemonOptions{Include: inc})
+	items := make([]*pokedexpb.PokemonItem, 0, len(results))
+	for _, r := range results {
+		items = append(items, itemMapper(r))
+	}
+	return &pokedexpb.BatchGetPokemonResponse{Items: items}, nil
+}
+
+func (s *pokedexServer) ListPokemon(req *pokedexpb.ListPokemonRequest, stream pokedexpb.PokedexService_ListPokemonServer) error {
+	inc, err := service.ParseInclude(req.GetInclude()...)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx := stream.Context()
+	opts := service.PokemonOptions{Include: inc}
+
+	for offset := 0; ; {
+		page, err := s.services.ListPokemon(ctx, int(req.GetPageSize()), offset)
+		if err != nil {
+			return toStatus(err).Err()
+		}
+		if len(page.Names) == 0 {
+			return nil
+		}
+
+		items := make([]*pokedexpb.PokemonItem, 0, len(page.Names))
+		if req.GetExpand() {
+			for _, r := range s.services.GetPokemonBatch(ctx, page.Names, opts) {
+				items = append(items, itemMapper(r))
+			}
+		} else {
+			for _, name := range page.Names {
+				items = append(items, &pokedexpb.PokemonItem{Name: name})
+			}
+		}
+		for _, item := range items {
+			if err := stream.Send(item); err != nil {
+				return err
+			}
+		}
+
+		offset = page.Offset + len(page.Names)
+		if offset >= page.Total {
+			return nil
+		}
+	}
+}
+
+// toStatus maps the errors of the services to a gRPC status. Like the HTTP API, the error code of the api
+// is sent along, as the reason of an ErrorInfo detail, with the "did you mean" names of not found errors.
+func toStatus(err error) *status.Status {
+	httpStatus, apiCode := handler.ErrorStatus(err)
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, service.ErrServiceUnavailable):
+		code = codes.Unavailable
+	case httpStatus == http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case httpStatus == http.StatusNotFound:
+		code = codes.NotFound
+	}
+
+	info := &errdetails.ErrorInfo{Reason: apiCode, Domain: errorDomain}
+	var notFound *service.NotFoundError
+	if errors.As(err, &notFound) && len(notFound.Suggestions) > 0 {
+		info.Metadata = map[string]string{"suggestions": strings.Join(notFound.Suggestions, ",")}
+	}
+
+	st, detailsErr := status.New(code, err.Error()).WithDetails(info)
+	if detailsErr != nil {
+		return status.New(code, err.Error())
+	}
+	return st
+}
+
+func itemMapper(r service.PokemonResult) *pokedexpb.PokemonItem {
+	if r.Err != nil {
+		_, apiCode := handler.ErrorStatus(r.Err)
+		return &pokedexpb.PokemonItem{
+			Name:  r.Name,
+			Error: &pokedexpb.Error{Code: apiCode, Message: r.Err.Error()},
+		}
+	}
+	return &pokedexpb.PokemonItem{Name: r.Name, Pokemon: pokemonMapper(r.Pokemon)}
+}
+
+func pokemonMapper(p model.Pokemon) *pokedexpb.Pokemon {
+	pokemon := &pokedexpb.Pokemon{
+		Id:          int32(p.ID),
+		Name:        p.Name,
+		Description: p.Description,
+		Habitat:     p.Habitat,
+		IsLegendary: p.IsLegendary,
+		Types:       p.Types,
+		Height:      int32(p.Height),
+		Weight:      int32(p.Weight),
+		Unavailable: p.Unavailable,
+	}
+	for _, st := range p.Stats {
+		pokemon.Stats = append(pokemon.Stats, &pokedexpb.Stat{Name: st.Name, BaseStat: int32(st.BaseStat)})
+	}
+	for _, a := range p.Abilities {
+		pokemon.Abilities = append(pokemon.Abilities, &pokedexpb.Ability{Name: a.Name, IsHidden: a.IsHidden})
+	}
+	return pokemon
+}
@@ -0,0 +1,242 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/fprojetto/pokedex-api/internal/api/rpc"
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/fprojetto/pokedex-api/pkg/pokedexpb"
+)
+
+var names = []string{"bulbasaur", "ivysaur", "venusaur", "charmander", "charmeleon"}
+
+func newClient(t *testing.T, services rpc.Services) pokedexpb.PokedexServiceClient {
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pokedexpb.RegisterPokedexServiceServer(srv, rpc.NewPokedexServer(services))
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pokedexpb.NewPokedexServiceClient(conn)
+}
+
+func getPokemon(ctx context.Context, name string, opts service.PokemonOptions) (model.Pokemon, error) {
+	switch name {
+	case "pikachu":
+		p := model.Pokemon{ID: 25, Name: "pikachu", Description: "An electric mouse.", Habitat: "forest", IsLegendary: client.BoolPtr(false)}
+		if opts.Include.Types {
+			p.Types = []string{"electric"}
+		}
+		return p, nil
+	case "pikachoo":
+		return model.Pokemon{}, &service.NotFoundError{Name: name, Suggestions: []string{"pikachu"}}
+	case "down":
+		return model.Pokemon{}, service.ErrServiceUnavailable
+	case "?":
+		return model.Pokemon{}, service.ErrInvalidName
+	default:
+		return model.Pokemon{}, service.ErrNotFound
+	}
+}
+
+func getBatch(ctx context.Context, names []string, opts service.PokemonOptions) []service.PokemonResult {
+	results := make([]service.PokemonResult, 0, len(names))
+	for _, n := range names {
+		if n == "missingno" {
+			results = append(results, service.PokemonResult{Name: n, Err: service.ErrNotFound})
+			continue
+		}
+		results = append(results, service.PokemonResult{Name: n, Pokemon: model.Pokemon{Name: n}})
+	}
+	return results
+}
+
+func listPokemon(ctx context.Context, limit, offset int) (model.NamePage, error) {
+	if limit < 1 || limit > 2 {
+		limit = 2
+	}
+	end := min(offset+limit, len(names))
+	return model.NamePage{Names: names[min(offset, end):end], Offset: offset, Limit: limit, Total: len(names)}, nil
+}
+
+func TestGetPokemon(t *testing.T) {
+	c := newClient(t, rpc.Services{GetPokemon: getPokemon})
+
+	tests := []struct {
+		name           string
+		req            *pokedexpb.GetPokemonRequest
+		expected       *pokedexpb.Pokemon
+		expectedCode   codes.Code
+		expectedReason string
+		expectedInfo   map[string]string
+	}{
+		{
+			name: "success",
+			req:  &pokedexpb.GetPokemonRequest{Name: "pikachu", Include: []string{"types"}},
+			expected: &pokedexpb.Pokemon{
+				Id:          25,
+				Name:        "pikachu",
+				Description: "An electric mouse.",
+				Habitat:     "forest",
+				IsLegendary: client.BoolPtr(false),
+				Types:       []string{"electric"},
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:           "not found with suggestions",
+			req:            &pokedexpb.GetPokemonRequest{Name: "pikachoo"},
+			expectedCode:   codes.NotFound,
+			expectedReason: "NOT_FOUND",
+			expectedInfo:   map[string]string{"suggestions": "pikachu"},
+		},
+		{
+			name:           "invalid name",
+			req:            &pokedexpb.GetPokemonRequest{Name: "?"},
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: "BAD_REQUEST",
+		},
+		{
+			name:           "upstream unavailable",
+			req:            &pokedexpb.GetPokemonRequest{Name: "down"},
+			expectedCode:   codes.Unavailable,
+			expectedReason: "INTERNAL_ERROR",
+		},
+		{
+			name:         "unknown include",
+			req:          &pokedexpb.GetPokemonRequest{Name: "pikachu", Include: []string{"moves"}},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := c.GetPokemon(context.Background(), tt.req)
+
+			st := status.Convert(err)
+			require.Equal(t, tt.expectedCode, st.Code(), st.Message())
+			if tt.expected != nil {
+				assert.Equal(t, tt.expected.String(), p.String())
+			}
+			if tt.expectedReason != "" {
+				require.Len(t, st.Details(), 1)
+				info := st.Details()[0].(*errdetails.ErrorInfo)
+				assert.Equal(t, tt.expectedReason, info.GetReason())
+				assert.Equal(t, tt.expectedInfo, info.GetMetadata())
+			}
+		})
+	}
+}
+
+func TestBatchGetPokemon(t *testing.T) {
+	c := newClient(t, rpc.Services{
+		GetPokemonBatch: getBatch,
+		GetPokemonBatchTranslated: func(ctx context.Context, names []string, opts service.PokemonOptions) []service.PokemonResult {
+			return []service.PokemonResult{{Name: names[0], Pokemon: model.Pokemon{Name: names[0], Description: "translated"}}}
+		},
+		MaxBatchSize: 2,
+	})
+
+	t.Run("items with pokemon or error", func(t *testing.T) {
+		resp, err := c.BatchGetPokemon(context.Background(), &pokedexpb.BatchGetPokemonRequest{Names: []string{"pikachu", " missingno "}})
+		require.NoError(t, err)
+
+		require.Len(t, resp.GetItems(), 2)
+		assert.Equal(t, "pikachu", resp.GetItems()[0].GetPokemon().GetName())
+		assert.Equal(t, "NOT_FOUND", resp.GetItems()[1].GetError().GetCode())
+		assert.Nil(t, resp.GetItems()[1].GetPokemon())
+	})
+	t.Run("translated", func(t *testing.T) {
+		resp, err := c.BatchGetPokemon(context.Background(), &pokedexpb.BatchGetPokemonRequest{Names: []string{"pikachu"}, Translated: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, "translated", resp.GetItems()[0].GetPokemon().GetDescription())
+	})
+	t.Run("too many names", func(t *testing.T) {
+		_, err := c.BatchGetPokemon(context.Background(), &pokedexpb.BatchGetPokemonRequest{Names: []string{"a", "b", "c"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("missing names", func(t *testing.T) {
+		_, err := c.BatchGetPokemon(context.Background(), &pokedexpb.BatchGetPokemonRequest{Names: []string{" "}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestListPokemon(t *testing.T) {
+	tests := []struct {
+		name         string
+		list         func(ctx context.Context, limit, offset int) (model.NamePage, error)
+		req          *pokedexpb.ListPokemonRequest
+		expected     []string
+		expectedCode codes.Code
+	}{
+		{
+			name:     "every page is streamed",
+			list:     listPokemon,
+			req:      &pokedexpb.ListPokemonRequest{},
+			expected: names,
+		},
+		{
+			name:     "expanded",
+			list:     listPokemon,
+			req:      &pokedexpb.ListPokemonRequest{PageSize: 2, Expand: true},
+			expected: names,
+		},
+		{
+			name: "listing failure",
+			list: func(ctx context.Context, limit, offset int) (model.NamePage, error) {
+				return model.NamePage{}, errors.Join(service.ErrServiceUnavailable, errors.New("timeout"))
+			},
+			req:          &pokedexpb.ListPokemonRequest{},
+			expectedCode: codes.Unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, rpc.Services{ListPokemon: tt.list, GetPokemonBatch: getBatch})
+
+			stream, err := c.ListPokemon(context.Background(), tt.req)
+			require.NoError(t, err)
+
+			var received []string
+			for {
+				item, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					assert.Equal(t, tt.expectedCode, status.Code(err))
+					break
+				}
+				received = append(received, item.GetName())
+				assert.Equal(t, tt.req.GetExpand(), item.GetPokemon() != nil)
+			}
+
+			assert.Equal(t, tt.expected, received)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimeout(t *testing.T) {
+	handler := func(delay time.Duration) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+			}
+			w.Header().Set("X-Handled", "true")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		}
+	}
+
+	tests := []struct {
+		name           string
+		timeout        time.Duration
+		delay          time.Duration
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "handled in time", timeout: time.Second, expectedStatus: http.StatusCreated},
+		{name: "timed out", timeout: 10 * time.Millisecond, delay: time.Second, expectedStatus: http.StatusServiceUnavailable, expectedCode: api.ErrCodeUnavailable},
+		{name: "no timeout", delay: 20 * time.Millisecond, expectedStatus: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/pokemon/pikachu", nil)
+			res := httptest.NewRecorder()
+			api.WithTimeout(tt.timeout, handler(tt.delay)).ServeHTTP(res, req)
+
+			assert.Equal(t, tt.expectedStatus, res.Code)
+			if tt.expectedCode == "" {
+				assert.Equal(t, "true", res.Header().Get("X-Handled"))
+				assert.JSONEq(t, `{"data":{}}`, res.Body.String())
+				return
+			}
+			assert.Empty(t, res.Header().Get("X-Handled"))
+			var env api.Envelope
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &env))
+			require.NotNil(t, env.Error)
+			assert.Equal(t, tt.expectedCode, env.Error.Code)
+		})
+	}
+}
+
+func TestBodyErrorStatus(t *testing.T) {
+	tooLarge := http.MaxBytesHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v any
+		err := json.NewDecoder(r.Body).Decode(&v)
+		status, code := api.BodyErrorStatus(err)
+		api.WriteError(w, r, status, code, err.Error())
+	}), 8)
+
+	req := httptest.NewRequest("POST", "/api/pokemon/batch", strings.NewReader(`{"names":["pikachu","bulbasaur"]}`))
+	res := httptest.NewRecorder()
+	tooLarge.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, res.Code)
+
+	status, code := api.BodyErrorStatus(&json.SyntaxError{})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, api.ErrCodeBadRequest, code)
+}
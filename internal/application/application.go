@@ -3,62 +3,419 @@ package application
 import (
 	"context"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/netip"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
 
 	"github.com/fprojetto/pokedex-api/internal/api"
 	"github.com/fprojetto/pokedex-api/internal/api-client/pokeapi"
 	"github.com/fprojetto/pokedex-api/internal/api-client/translationapi"
+	"github.com/fprojetto/pokedex-api/internal/api-client/webhook"
+	"github.com/fprojetto/pokedex-api/internal/api/graphql"
 	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/api/rpc"
 	"github.com/fprojetto/pokedex-api/internal/config"
+	"github.com/fprojetto/pokedex-api/internal/model"
 	"github.com/fprojetto/pokedex-api/internal/service"
+	"github.com/fprojetto/pokedex-api/pkg/cache"
+	"github.com/fprojetto/pokedex-api/pkg/pokedexpb"
 	"github.com/fprojetto/pokedex-api/pkg/server"
 )
 
-func BuildAPI(pokemonGetter handler.PokemonGetter, pokemonGetterTranslated handler.PokemonGetterTranslator) http.Handler {
-	getPokemonHandler := handler.GetPokemon(pokemonGetter)
-	getPokemonTranslatedHandler := handler.GetPokemonTranslated(pokemonGetterTranslated)
-	pokemonMux := api.NewPokemonRouter(
-		getPokemonHandler,
-		getPokemonTranslatedHandler,
-	)
+// Services groups the business functions the http handlers are built on.
+type Services struct {
+	PokemonGetter           handler.PokemonGetter
+	PokemonGetterTranslator handler.PokemonGetterTranslator
+	PokemonBatchGetter      handler.PokemonBatchGetter
+	PokemonBatchTranslator  handler.PokemonBatchGetter
+	TranslationStreamer     handler.PokemonTranslationStreamer
+	PokemonLister           handler.PokemonLister
+	PokemonSearcher         handler.PokemonSearcher
+	EvolutionGetter         handler.EvolutionGetter
+	SpriteGetter            handler.SpriteGetter
+	TypeGetter              handler.TypeGetter
+	WeaknessGetter          handler.WeaknessGetter
+	PokemonComparer         handler.PokemonComparer
+	HabitatLister           handler.HabitatLister
+	HabitatPokemonLister    handler.HabitatPokemonLister
+	RandomPokemonGetter     handler.RandomPokemonGetter
+	RandomPokemonTranslator handler.RandomPokemonGetter
+	DailyPokemonGetter      handler.DailyPokemonGetter
+	DailyPokemonTranslator  handler.DailyPokemonGetter
+	GraphQLResolvers        graphql.Resolvers
+	TranslationJobSubmitter handler.TranslationJobSubmitter
+	TranslationJobGetter    handler.TranslationJobGetter
+	TranslationJobCanceler  handler.TranslationJobGetter
+	WebhookSubscriber       handler.WebhookSubscriber
+	WebhookLister           handler.WebhookLister
+	WebhookUnsubscriber     handler.WebhookUnsubscriber
+	DeadLetterLister        handler.DeadLetterLister
+	ConfigReloader          handler.ConfigReloader
+	ConfigReloadStatsGetter handler.ConfigReloadStatsGetter
+}
+
+func BuildAPI(services Services, cfg config.Config) http.Handler {
+	pokemonMux := api.NewPokemonRouter(api.PokemonHandlers{
+		GetPokemon:           handler.GetPokemon(services.PokemonGetter),
+		GetPokemonTranslated: handler.GetPokemonTranslated(services.PokemonGetterTranslator),
+		StreamTranslated: handler.StreamPokemonTranslated(
+			services.TranslationStreamer,
+			cfg.StreamMaxNames,
+			cfg.StreamHeartbeatInterval,
+		),
+		GetPokemonBatch: handler.GetPokemonBatch(
+			services.PokemonBatchGetter,
+			services.PokemonBatchTranslator,
+			cfg.MaxBatchSize,
+		),
+		ListPokemon:          handler.ListPokemon(services.PokemonLister, services.PokemonBatchGetter),
+		SearchPokemon:        handler.SearchPokemon(services.PokemonSearcher, cfg.MaxSearchResults),
+		GetPokemonEvolutions: handler.GetPokemonEvolutions(services.EvolutionGetter),
+		GetPokemonSprite:     handler.GetPokemonSprite(services.SpriteGetter),
+		GetPokemonWeaknesses: handler.GetPokemonWeaknesses(services.WeaknessGetter),
+		GetType:              handler.GetType(services.TypeGetter),
+		ComparePokemon:       handler.ComparePokemon(services.PokemonComparer),
+		ListHabitats:         handler.ListHabitats(services.HabitatLister),
+		ListHabitatPokemon: handler.ListHabitatPokemon(
+			services.HabitatPokemonLister,
+			services.PokemonBatchGetter,
+			services.PokemonBatchTranslator,
+		),
+		GetRandomPokemon: handler.GetRandomPokemon(services.RandomPokemonGetter, services.RandomPokemonTranslator),
+		GetDailyPokemon:  handler.GetDailyPokemon(services.DailyPokemonGetter, services.DailyPokemonTranslator, time.Now),
+		GraphQL: graphql.Handler(services.GraphQLResolvers, graphql.Limits{
+			MaxDepth:      cfg.GraphQLMaxDepth,
+			MaxComplexity: cfg.GraphQLMaxComplexity,
+			MaxNames:      cfg.MaxBatchSize,
+			Introspection: cfg.GraphQLIntrospection,
+		}),
+		SubmitTranslationJob: handler.SubmitTranslationJob(services.TranslationJobSubmitter, cfg.JobMaxNames),
+		GetJob:               handler.GetJob(services.TranslationJobGetter),
+		CancelJob:            handler.CancelJob(services.TranslationJobCanceler),
+		CreateWebhook:        handler.CreateWebhook(services.WebhookSubscriber),
+		ListWebhooks:         handler.ListWebhooks(services.WebhookLister),
+		DeleteWebhook:        handler.DeleteWebhook(services.WebhookUnsubscriber),
+		ListDeadLetters:      handler.ListDeadLetters(services.DeadLetterLister),
+		ReloadConfig:         handler.ReloadConfig(services.ConfigReloader),
+		GetConfigReloads:     handler.GetConfigReloads(services.ConfigReloadStatsGetter),
+	}, api.RouterConfig{
+		AdminToken:               cfg.AdminToken,
+		HandlerTimeout:           cfg.HandlerTimeout,
+		TranslatedHandlerTimeout: cfg.TranslatedHandlerTimeout,
+	})
 
 	return pokemonMux
 }
 
-func Run(ctx context.Context, cfg config.Config) error {
+// BuildGRPC returns the registration of the gRPC services, built on the same services as BuildAPI.
+func BuildGRPC(services Services, cfg config.Config) func(s *grpc.Server) {
+	pokedexServer := rpc.NewPokedexServer(rpc.Services{
+		GetPokemon:                services.PokemonGetter,
+		GetPokemonTranslated:      services.PokemonGetterTranslator,
+		GetPokemonBatch:           services.PokemonBatchGetter,
+		GetPokemonBatchTranslated: services.PokemonBatchTranslator,
+		ListPokemon:               services.PokemonLister,
+		MaxBatchSize:              cfg.MaxBatchSize,
+	})
+
+	return func(s *grpc.Server) {
+		pokedexpb.RegisterPokedexServiceServer(s, pokedexServer)
+	}
+}
+
+// Run runs the application until ctx is done or the process is terminated. load reloads the configuration
+// on SIGHUP and on POST /api/admin/config/reload, see reloader.
+func Run(ctx context.Context, cfg config.Config, load func() (*config.Config, error)) error {
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.LogLevel)
+	slog.SetDefault(newLogger(os.Stderr, logLevel, cfg.LogFormat))
+
 	// build api
-	pokeAPIClient, err := pokeapi.NewClient(cfg.PokemonAPIURL)
+	pokeAPIClient, err := pokeapi.NewClient(cfg.PokemonAPIURL, cfg.PokemonAPIClient)
+	if err != nil {
+		return err
+	}
+	translationAPIClient, err := translationapi.NewClient(cfg.TranslationAPIURL, cfg.TranslationAPIClient)
 	if err != nil {
 		return err
 	}
-	translationAPIClient, err := translationapi.NewClient(cfg.TranslationAPIURL)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	nameIndex := service.NewNameIndex(nil)
+	if cfg.NameIndexRefreshInterval > 0 {
+		go refreshNameIndex(ctx, nameIndex, pokeAPIClient.PokemonList, cfg.NameIndexRefreshInterval)
+	}
+
+	pokemonCache := cache.New[string, model.Pokemon](cfg.CacheTTL, cfg.CacheMaxEntries)
+	pokemonInfo := service.PokemonCacheService(pokeAPIClient.PokemonInfo, pokemonCache)
+	evolutionCache := cache.New[string, model.EvolutionChain](cfg.CacheTTL, cfg.CacheMaxEntries)
+	evolutionChain := service.CachedLookup(pokeAPIClient.EvolutionChain, evolutionCache)
+
+	// type charts are static, hence their dedicated longer TTL
+	typeCache := cache.New[string, model.TypeRelations](cfg.TypeCacheTTL, cfg.CacheMaxEntries)
+	typeRelations := service.CachedLookup(pokeAPIClient.TypeRelations, typeCache)
+
+	habitatCache := cache.New[string, []string](cfg.CacheTTL, cfg.CacheMaxEntries)
+	habitatMembers := service.CachedLookup(pokeAPIClient.HabitatMembers, habitatCache)
+
+	spriteCache, err := cache.NewDiskCache(cfg.SpriteCacheDir, int64(cfg.SpriteCacheMaxBytes))
 	if err != nil {
 		return err
 	}
 
-	pokemonGetterService := service.PokemonGetterService(pokeAPIClient.PokemonInfo)
-	pokemonGetterTranslatedService := service.PokemonGetterTranslatorService(
-		pokeAPIClient.PokemonInfo,
+	configReloader := &reloader{
+		load: load,
+		apply: func(cfg config.Config) error {
+			// the urls are checked before changing anything, so that a rejected reload is not half applied
+			if err := pokeapi.CheckURL(cfg.PokemonAPIURL); err != nil {
+				return err
+			}
+			if err := translationapi.CheckURL(cfg.TranslationAPIURL); err != nil {
+				return err
+			}
+
+			logLevel.Set(cfg.LogLevel)
+			pokemonCache.SetTTL(cfg.CacheTTL)
+			evolutionCache.SetTTL(cfg.CacheTTL)
+			habitatCache.SetTTL(cfg.CacheTTL)
+			typeCache.SetTTL(cfg.TypeCacheTTL)
+			if cfg.PokemonAPIURL != pokeAPIClient.URL() {
+				_ = pokeAPIClient.SetURL(cfg.PokemonAPIURL)
+				// the data of the previous PokeAPI is not served anymore
+				pokemonCache.Clear()
+				evolutionCache.Clear()
+				typeCache.Clear()
+				habitatCache.Clear()
+				spriteCache.Clear()
+			}
+			// the translations are not cached
+			_ = translationAPIClient.SetURL(cfg.TranslationAPIURL)
+			return nil
+		},
+		now:     time.Now,
+		running: cfg,
+	}
+
+	pokemonGetterService := service.PokemonSuggestService(
+		service.PokemonGetterService(pokemonInfo),
+		nameIndex,
+		cfg.MaxSuggestions,
+	)
+	pokemonGetterTranslatedService := service.PokemonSuggestService(
+		service.PokemonGetterTranslatorService(
+			pokemonInfo,
+			translationAPIClient.Translate,
+		),
+		nameIndex,
+		cfg.MaxSuggestions,
+	)
+	pokemonBatchService := service.PokemonBatchService(pokemonGetterService, cfg.BatchConcurrency)
+	pokemonBatchTranslatorService := service.PokemonBatchTranslatorService(
+		pokemonBatchService,
 		translationAPIClient.Translate,
+		cfg.BatchConcurrency,
+		cfg.TranslationBudget,
 	)
-	apiMux := BuildAPI(
+	randomPokemonService := service.PokemonRandomService(
 		pokemonGetterService,
+		pokeAPIClient.PokemonList,
+		habitatMembers,
+		service.LegendarySpecies(),
+		cfg.FeaturedMaxAttempts,
+	)
+	dailyPokemonService := service.PokemonDailyService(
+		pokemonGetterService,
+		pokeAPIClient.PokemonList,
+		cfg.DailyLocation,
+		cfg.DailySeed,
+		cfg.FeaturedMaxAttempts,
+	)
+	evolutionService := service.PokemonEvolutionService(evolutionChain)
+	// the webhooks only reach public addresses, unless allowed otherwise
+	var allowWebhookAddr func(netip.Addr) bool
+	var resolveWebhookHost service.WebhookResolver
+	if !cfg.WebhookAllowPrivateURLs {
+		allowWebhookAddr = service.IsPublicAddr
+		resolveWebhookHost = func(ctx context.Context, host string) ([]netip.Addr, error) {
+			return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		}
+	}
+	webhooks := service.NewWebhooks(
+		webhook.NewClient(cfg.WebhookClient, allowWebhookAddr).Deliver,
+		resolveWebhookHost,
+		service.WebhookRetryPolicy{
+			MaxAttempts: cfg.WebhookMaxAttempts,
+			Backoff:     cfg.WebhookBackoff,
+			MaxBackoff:  cfg.WebhookMaxBackoff,
+		},
+		service.WebhookLimits{
+			MaxSubscriptions: cfg.WebhookMaxSubscriptions,
+			Workers:          cfg.WebhookWorkers,
+			MaxQueued:        cfg.WebhookMaxQueued,
+			MaxDeadLetters:   cfg.WebhookMaxDeadLetters,
+		},
+		time.Now,
+	)
+	translationJobs := service.NewTranslationJobs(
 		pokemonGetterTranslatedService,
+		func(job service.TranslationJob) {
+			event := service.EventJobCompleted
+			if job.Status == service.JobCanceled {
+				event = service.EventJobCanceled
+			}
+			webhooks.Publish(event, handler.JobEventMapper(job))
+		},
+		cfg.JobWorkers,
+		cfg.JobMaxQueued,
+		cfg.JobTTL,
+		time.Now,
 	)
+	services := Services{
+		PokemonGetter:           pokemonGetterService,
+		PokemonGetterTranslator: pokemonGetterTranslatedService,
+		PokemonBatchGetter:      pokemonBatchService,
+		PokemonBatchTranslator: service.PokemonBatchNotifierService(
+			pokemonBatchTranslatorService,
+			func(results []service.PokemonResult) {
+				webhooks.Publish(service.EventBatchTranslated, handler.BatchEventMapper(results))
+			},
+		),
+		TranslationStreamer: service.PokemonTranslationStreamService(
+			pokemonGetterService,
+			translationAPIClient.Translate,
+			cfg.BatchConcurrency,
+		),
+		PokemonLister:           service.PokemonListService(pokeAPIClient.PokemonList, cfg.MaxPageSize),
+		PokemonSearcher:         service.PokemonSearchService(nameIndex),
+		EvolutionGetter:         evolutionService,
+		SpriteGetter:            service.PokemonSpriteService(pokeAPIClient.SpriteURL, pokeAPIClient.Sprite, spriteCache),
+		TypeGetter:              service.TypeService(typeRelations),
+		WeaknessGetter:          service.PokemonWeaknessService(pokemonGetterService, typeRelations),
+		PokemonComparer:         service.PokemonCompareService(pokemonGetterService, typeRelations),
+		HabitatLister:           service.PokemonListService(pokeAPIClient.HabitatList, cfg.MaxPageSize),
+		HabitatPokemonLister:    service.HabitatPokemonService(habitatMembers, cfg.MaxPageSize),
+		RandomPokemonGetter:     randomPokemonService,
+		RandomPokemonTranslator: service.PokemonTranslatedService(randomPokemonService, translationAPIClient.Translate),
+		DailyPokemonGetter:      dailyPokemonService,
+		DailyPokemonTranslator:  service.PokemonTranslatedService(dailyPokemonService, translationAPIClient.Translate),
+		GraphQLResolvers: graphql.Resolvers{
+			GetPokemonBatch: pokemonBatchService,
+			GetEvolutions:   evolutionService,
+			Describe:        service.PokemonDescriptionService(translationAPIClient.Translate),
+		},
+		TranslationJobSubmitter: translationJobs.Submit,
+		TranslationJobGetter:    translationJobs.Get,
+		TranslationJobCanceler:  translationJobs.Cancel,
+		WebhookSubscriber:       webhooks.Subscribe,
+		WebhookLister:           webhooks.Subscriptions,
+		WebhookUnsubscriber:     webhooks.Unsubscribe,
+		DeadLetterLister:        webhooks.DeadLetters,
+		ConfigReloader:          configReloader.Reload,
+		ConfigReloadStatsGetter: configReloader.Stats,
+	}
+	apiMux := BuildAPI(services, cfg)
 
 	// build and run http server
+	httpNetwork, httpAddr := listenAddr(cfg.Addr, cfg.Socket)
 	httpServer, err := server.NewHTTPServer(server.ServerConfig{
-		Addr:            cfg.Addr,
-		ShutdownTimeout: cfg.ShutdownTimeout,
-		OnShutdown:      shutdown,
+		Addr:              httpAddr,
+		Network:           httpNetwork,
+		SocketMode:        cfg.SocketMode,
+		OpsAddr:           cfg.OpsAddr,
+		GracefulRestart:   cfg.GracefulRestart,
+		ShutdownTimeout:   cfg.ShutdownTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		MaxBodyBytes:      int64(cfg.MaxBodyBytes),
+		TLS:               cfg.TLS,
+		Debug:             cfg.Debug,
+		OnShutdown: func(ctx context.Context) {
+			shutdown()
+			// running jobs get the rest of the shutdown timeout to complete, queued ones included
+			if err := translationJobs.Shutdown(ctx); err != nil {
+				log.Printf("translation jobs canceled at shutdown: %v", err)
+			}
+			// after the jobs, whose events are delivered too
+			if err := webhooks.Shutdown(ctx); err != nil {
+				log.Printf("webhook deliveries given up at shutdown: %v", err)
+			}
+		},
+		OnReload: func(ctx context.Context) {
+			// failures are logged, the running configuration is kept
+			_, _ = configReloader.Reload(ctx)
+		},
 	}, apiMux)
 	if err != nil {
 		return err
 	}
 
-	return httpServer.Run(ctx)
+	if !cfg.GRPCEnabled() {
+		return httpServer.Run(ctx)
+	}
+
+	grpcNetwork, grpcAddr := listenAddr(cfg.GRPCAddr, cfg.GRPCSocket)
+	grpcServer, err := server.NewGRPCServer(server.ServerConfig{
+		Addr:            grpcAddr,
+		Network:         grpcNetwork,
+		SocketMode:      cfg.SocketMode,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+		TLS:             cfg.TLS,
+	}, BuildGRPC(services, cfg))
+	if err != nil {
+		return err
+	}
+
+	// both servers run until the first one stops, which stops the other
+	errCh := make(chan error, 2)
+	go func() { errCh <- httpServer.Run(ctx) }()
+	go func() { errCh <- grpcServer.Run(ctx) }()
+
+	err = <-errCh
+	cancel()
+	if grpcErr := <-errCh; err == nil {
+		err = grpcErr
+	}
+	return err
+}
+
+// refreshNameIndex reloads the name index from the pokeapi now and then every interval, until ctx is done.
+func refreshNameIndex(ctx context.Context, idx *service.NameIndex, lister service.PokemonLister, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := service.RefreshNameIndex(ctx, idx, lister, 500); err != nil {
+			log.Printf("failed to refresh name index: %v", err)
+		} else {
+			log.Printf("name index refreshed with %d names", idx.Len())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
 func shutdown() {
 	log.Println("shutting down application")
 }
+
+// listenAddr returns the network and the address a server listens on: its unix socket when set, else its tcp address.
+func listenAddr(addr, socket string) (string, string) {
+	if socket != "" {
+		return "unix", socket
+	}
+	return "tcp", addr
+}
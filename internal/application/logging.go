@@ -0,0 +1,16 @@
+package application
+
+import (
+	"io"
+	"log/slog"
+)
+
+// newLogger returns the logger of the application, whose level can be changed while running.
+// Once set as the default logger the output of the log package goes through it too, at the info level.
+func newLogger(w io.Writer, level *slog.LevelVar, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"expvar"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/config"
+)
+
+// reloadMetrics counts the reloads of the configuration that succeeded and failed, like reloader.Stats
+// but served under /debug/vars.
+var reloadMetrics = expvar.NewMap("config_reloads")
+
+// reloader reloads the configuration with load, and applies the reloadable settings to the running
+// application with apply: the log level, the cache TTLs and the upstream urls. apply changes nothing
+// when it fails. The other settings, e.g. the ports, are only applied at the next restart.
+type reloader struct {
+	load  func() (*config.Config, error)
+	apply func(cfg config.Config) error
+	now   func() time.Time
+
+	mu sync.Mutex
+	// running is the configuration applied, the settings needing a restart aside.
+	running config.Config
+	stats   handler.ConfigReloadStats
+}
+
+func (r *reloader) Reload(_ context.Context) (handler.ConfigReload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := r.load()
+	if err == nil {
+		err = r.apply(*cfg)
+	}
+	if err != nil {
+		r.stats.Failed++
+		reloadMetrics.Add("failed", 1)
+		slog.Error("failed to reload the configuration", "err", err)
+		return handler.ConfigReload{}, err
+	}
+
+	changed := config.Changed(&r.running, cfg)
+	copyReloadable(&r.running, *cfg)
+	result := handler.ConfigReload{
+		ReloadedAt:      r.now(),
+		RestartRequired: config.Changed(&r.running, cfg),
+	}
+	result.Applied = slices.DeleteFunc(changed, func(key string) bool { return slices.Contains(result.RestartRequired, key) })
+
+	r.stats.Succeeded++
+	r.stats.LastReloadedAt = &result.ReloadedAt
+	reloadMetrics.Add("succeeded", 1)
+	slog.Info("configuration reloaded", "applied", result.Applied)
+	if len(result.RestartRequired) > 0 {
+		slog.Warn("configuration changes need a restart", "keys", result.RestartRequired)
+	}
+	return result, nil
+}
+
+// Stats counts the reloads since the start.
+func (r *reloader) Stats(_ context.Context) handler.ConfigReloadStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stats
+}
+
+// copyReloadable copies the settings applied by a reload from cfg to running.
+func copyReloadable(running *config.Config, cfg config.Config) {
+	running.LogLevel = cfg.LogLevel
+	running.CacheTTL = cfg.CacheTTL
+	running.TypeCacheTTL = cfg.TypeCacheTTL
+	running.PokemonAPIURL = cfg.PokemonAPIURL
+	running.TranslationAPIURL = cfg.TranslationAPIURL
+}
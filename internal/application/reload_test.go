@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/api/handler"
+	"github.com/fprojetto/pokedex-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloader(t *testing.T) {
+	getenv := func(vars map[string]string) func(string) string {
+		return func(name string) string {
+			if v, ok := vars[name]; ok {
+				return v
+			}
+			return map[string]string{"POKEMON_API_URL": "https://pokeapi.co", "TRANSLATION_API_URL": "https://api.funtranslations.com"}[name]
+		}
+	}
+	running, err := config.Load(nil, getenv(nil))
+	require.NoError(t, err)
+
+	var vars map[string]string
+	var applied []config.Config
+	now := time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)
+	r := &reloader{
+		load: func() (*config.Config, error) { return config.Load(nil, getenv(vars)) },
+		apply: func(cfg config.Config) error {
+			applied = append(applied, cfg)
+			return nil
+		},
+		now:     func() time.Time { return now },
+		running: *running,
+	}
+
+	vars = map[string]string{"CACHE_TTL": "1m", "POKEMON_API_URL": "https://pokeapi.example.com", "PORT": "8000"}
+	result, err := r.Reload(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, now, result.ReloadedAt)
+	assert.Equal(t, []string{"upstreams.pokeapi.url", "cache.ttl"}, result.Applied)
+	assert.Equal(t, []string{"server.port"}, result.RestartRequired)
+	require.Len(t, applied, 1)
+	assert.Equal(t, time.Minute, applied[0].CacheTTL)
+
+	t.Run("changes needing a restart are reported until applied", func(t *testing.T) {
+		result, err := r.Reload(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, result.Applied)
+		assert.Equal(t, []string{"server.port"}, result.RestartRequired)
+	})
+
+	t.Run("invalid configuration is not applied", func(t *testing.T) {
+		vars = map[string]string{"CACHE_TTL": "soon"}
+		_, err := r.Reload(context.Background())
+		assert.ErrorContains(t, err, "invalid CACHE_TTL environment variable")
+		assert.Len(t, applied, 2)
+		assert.Equal(t, time.Minute, r.running.CacheTTL)
+	})
+
+	t.Run("failures to apply are reported", func(t *testing.T) {
+		vars = nil
+		r.apply = func(cfg config.Config) error { return errors.New("apply failed") }
+		_, err := r.Reload(context.Background())
+		assert.EqualError(t, err, "apply failed")
+	})
+
+	t.Run("reloads are counted", func(t *testing.T) {
+		assert.Equal(t, handler.ConfigReloadStats{Succeeded: 2, Failed: 2, LastReloadedAt: &now}, r.Stats(context.Background()))
+	})
+}
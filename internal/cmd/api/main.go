@@ -3,19 +3,35 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"log"
+	"os"
 
 	"github.com/fprojetto/pokedex-api/internal/application"
 	"github.com/fprojetto/pokedex-api/internal/config"
 )
 
 func main() {
-	cfg, err := config.New()
+	load := func() (*config.Config, error) {
+		return config.Load(os.Args[1:], os.Getenv)
+	}
+
+	cfg, err := load()
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
 		log.Fatal(errors.Join(errors.New("failed to load configuration for the app"), err))
 	}
 
-	if err := application.Run(context.Background(), *cfg); err != nil {
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if err := application.Run(context.Background(), *cfg, load); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -1,42 +1,325 @@
 package config
 
 import (
+	"crypto/tls"
 	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
 	"time"
+	// embedded so that DAILY_TIMEZONE works in images without tzdata
+	_ "time/tzdata"
+
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/fprojetto/pokedex-api/pkg/server"
 )
 
 type Config struct {
-	Addr            string
+	// File is the configuration file read, if any.
+	File string
+	// PrintConfig asks to print the configuration instead of running the application.
+	PrintConfig bool
+
+	Addr string
+	// GRPCAddr is the address of the gRPC server, which only runs when it or GRPCSocket is set.
+	GRPCAddr        string
 	ShutdownTimeout time.Duration
+	// OpsAddr is the address of the ops endpoints, served with the api when empty.
+	OpsAddr string
+
+	// Socket and GRPCSocket are the unix sockets of the servers, replacing Addr and GRPCAddr when set.
+	Socket          string
+	GRPCSocket      string
+	SocketMode      os.FileMode
+	GracefulRestart bool
+
+	ReadHeaderTimeout        time.Duration
+	ReadTimeout              time.Duration
+	WriteTimeout             time.Duration
+	IdleTimeout              time.Duration
+	MaxHeaderBytes           int
+	MaxBodyBytes             int
+	HandlerTimeout           time.Duration
+	TranslatedHandlerTimeout time.Duration
+
+	TLS server.TLSConfig
+
+	// AdminToken is the bearer token of the admin endpoints, which are disabled when it is empty.
+	AdminToken string
+	// Debug enables the profiling and runtime stats endpoints of the ops mux.
+	Debug server.DebugConfig
+
+	LogLevel  slog.Level
+	LogFormat string
+
+	PokemonAPIURL        string
+	PokemonAPIClient     client.Config
+	TranslationAPIURL    string
+	TranslationAPIClient client.Config
+	WebhookClient        client.Config
+
+	MaxBatchSize     int
+	BatchConcurrency int
+	MaxPageSize      int
+
+	TranslationBudget int
+
+	StreamMaxNames          int
+	StreamHeartbeatInterval time.Duration
+
+	JobWorkers   int
+	JobMaxQueued int
+	JobMaxNames  int
+	JobTTL       time.Duration
+
+	WebhookMaxAttempts      int
+	WebhookBackoff          time.Duration
+	WebhookMaxBackoff       time.Duration
+	WebhookMaxDeadLetters   int
+	WebhookMaxSubscriptions int
+	WebhookWorkers          int
+	WebhookMaxQueued        int
+	// WebhookAllowPrivateURLs accepts the webhooks resolving to loopback, private or link-local addresses.
+	WebhookAllowPrivateURLs bool
+
+	MaxSearchResults         int
+	MaxSuggestions           int
+	NameIndexRefreshInterval time.Duration
+
+	CacheTTL        time.Duration
+	CacheMaxEntries int
+	TypeCacheTTL    time.Duration
+
+	SpriteCacheDir      string
+	SpriteCacheMaxBytes int
 
-	PokemonAPIURL     string
-	TranslationAPIURL string
+	DailyLocation       *time.Location
+	DailySeed           string
+	FeaturedMaxAttempts int
+
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+	GraphQLIntrospection bool
 }
 
-func New() (*Config, error) {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+// Load reads the configuration from, by increasing precedence, the defaults, the configuration file given
+// with -config or CONFIG_FILE, the environment and the command line flags in args.
+// Every invalid setting is reported in the error, not only the first one.
+func Load(args []string, getenv func(string) string) (*Config, error) {
+	cfg := defaults()
+	opts := cfg.options()
+
+	fs := flag.NewFlagSet("pokedex-api", flag.ContinueOnError)
+	fs.StringVar(&cfg.File, "config", getenv("CONFIG_FILE"), "the YAML or JSON configuration `file` (env CONFIG_FILE)")
+	fs.BoolVar(&cfg.PrintConfig, "print-config", false, "print the configuration, secrets redacted, and exit")
+	// flags are only recorded while parsing, to be applied after the file and the environment
+	flags := make(map[string]string)
+	for _, o := range opts {
+		_, isBool := o.value.(boolValue)
+		fv := &flagValue{name: o.flag(), def: o.value.String(), isBool: isBool, flags: flags}
+		fs.Var(fv, o.flag(), fmt.Sprintf("%s (env %s)", o.usage, o.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", fs.Args())
 	}
 
-	pokemonAPIURL := os.Getenv("POKEMON_API_URL")
-	if pokemonAPIURL == "" {
-		return nil, errors.New("missing POKEMON_API_URL environment variable")
+	var errs []error
+	if cfg.File != "" {
+		values, err := readFile(cfg.File)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			i := slices.IndexFunc(opts, func(o option) bool { return o.key == key })
+			if i < 0 {
+				errs = append(errs, fmt.Errorf("unknown key %s in %s", key, cfg.File))
+				continue
+			}
+			if err := opts[i].value.Set(values[key]); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s in %s: %w", key, cfg.File, err))
+			}
+		}
 	}
+	for _, o := range opts {
+		if v := getenv(o.env); v != "" {
+			if err := o.value.Set(v); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s environment variable: %w", o.env, err))
+			}
+		}
+	}
+	for _, o := range opts {
+		if v, ok := flags[o.flag()]; ok {
+			if err := o.value.Set(v); err != nil {
+				errs = append(errs, fmt.Errorf("invalid -%s flag: %w", o.flag(), err))
+			}
+		}
+	}
+	errs = append(errs, cfg.validate()...)
 
-	translationAPIURL := os.Getenv("TRANSLATION_API_URL")
-	if translationAPIURL == "" {
-		return nil, errors.New("missing TRANSLATION_API_URL environment variable")
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
+	return &cfg, nil
+}
 
-	cfg := Config{
-		Addr:            ":" + port,
+// Changed returns the keys of the settings whose values differ between a and b, in the order of the configuration file.
+func Changed(a, b *Config) []string {
+	keys := []string{}
+	bOpts := b.options()
+	for i, o := range a.options() {
+		if o.value.String() != bOpts[i].value.String() {
+			keys = append(keys, o.key)
+		}
+	}
+	return keys
+}
+
+func defaults() Config {
+	webhookClient := client.DefaultConfig()
+	webhookClient.Timeout = 10 * time.Second
+
+	return Config{
+		Addr:            ":8080",
 		ShutdownTimeout: 5 * time.Second,
 
-		PokemonAPIURL:     pokemonAPIURL,
-		TranslationAPIURL: translationAPIURL,
+		SocketMode: 0o660,
+
+		ReadHeaderTimeout:        5 * time.Second,
+		ReadTimeout:              30 * time.Second,
+		WriteTimeout:             time.Minute,
+		IdleTimeout:              2 * time.Minute,
+		MaxHeaderBytes:           1 << 20,
+		MaxBodyBytes:             1 << 20,
+		HandlerTimeout:           10 * time.Second,
+		TranslatedHandlerTimeout: 30 * time.Second,
+
+		TLS: server.TLSConfig{
+			ReloadInterval: 10 * time.Second,
+			MinVersion:     tls.VersionTLS12,
+		},
+
+		LogLevel:  slog.LevelInfo,
+		LogFormat: "text",
+
+		PokemonAPIClient:     client.DefaultConfig(),
+		TranslationAPIClient: client.DefaultConfig(),
+		WebhookClient:        webhookClient,
+
+		MaxBatchSize:     20,
+		BatchConcurrency: 4,
+		MaxPageSize:      50,
+
+		TranslationBudget: 20,
+
+		StreamMaxNames:          200,
+		StreamHeartbeatInterval: 15 * time.Second,
+
+		JobWorkers:   2,
+		JobMaxQueued: 100,
+		JobMaxNames:  1000,
+		JobTTL:       time.Hour,
+
+		WebhookMaxAttempts:      5,
+		WebhookBackoff:          time.Second,
+		WebhookMaxBackoff:       time.Minute,
+		WebhookMaxDeadLetters:   100,
+		WebhookMaxSubscriptions: 100,
+		WebhookWorkers:          4,
+		WebhookMaxQueued:        1000,
+
+		MaxSearchResults: 20,
+		MaxSuggestions:   3,
+
+		CacheTTL:        10 * time.Minute,
+		CacheMaxEntries: 1000,
+		TypeCacheTTL:    24 * time.Hour,
+
+		SpriteCacheDir:      filepath.Join(os.TempDir(), "pokedex-api", "sprites"),
+		SpriteCacheMaxBytes: 64 << 20,
+
+		DailyLocation:       time.UTC,
+		DailySeed:           "pokedex-api",
+		FeaturedMaxAttempts: 20,
+
+		GraphQLMaxDepth:      10,
+		GraphQLMaxComplexity: 200,
 	}
+}
 
-	return &cfg, nil
+// GRPCEnabled reports whether the gRPC server runs, on GRPCAddr or GRPCSocket.
+func (c *Config) GRPCEnabled() bool {
+	return c.GRPCAddr != "" || c.GRPCSocket != ""
+}
+
+// validate checks the rules involving more than a single setting, or that a missing setting would break.
+func (c *Config) validate() []error {
+	var errs []error
+
+	upstreams := []struct{ key, env, url string }{
+		{"upstreams.pokeapi.url", "POKEMON_API_URL", c.PokemonAPIURL},
+		{"upstreams.translation.url", "TRANSLATION_API_URL", c.TranslationAPIURL},
+	}
+	for _, upstream := range upstreams {
+		if upstream.url == "" {
+			errs = append(errs, fmt.Errorf("missing %s (env %s)", upstream.key, upstream.env))
+			continue
+		}
+		u, err := url.Parse(upstream.url)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid %s: must be an absolute http or https url", upstream.key))
+		}
+	}
+	if c.ShutdownTimeout == 0 {
+		errs = append(errs, errors.New("invalid server.shutdown_timeout: must be a positive duration"))
+	}
+	// a response timing out on its write deadline is cut short, rather than the error of the handler timeout
+	handlerTimeouts := []struct {
+		key     string
+		timeout time.Duration
+	}{
+		{"server.handler_timeout", c.HandlerTimeout},
+		{"server.translated_handler_timeout", c.TranslatedHandlerTimeout},
+	}
+	for _, handler := range handlerTimeouts {
+		if c.WriteTimeout > 0 && handler.timeout >= c.WriteTimeout {
+			errs = append(errs, fmt.Errorf("invalid %s: must be less than server.write_timeout", handler.key))
+		}
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("invalid tls.key_file: tls.cert_file and tls.key_file must be set together"))
+	}
+	if c.TLS.ClientCAFile != "" && c.TLS.CertFile == "" {
+		errs = append(errs, errors.New("invalid tls.client_ca_file: mutual TLS requires tls.cert_file"))
+	}
+	if c.TLS.MinVersion == tls.VersionTLS13 && len(c.TLS.CipherSuites) > 0 {
+		errs = append(errs, errors.New("invalid tls.cipher_suites: the cipher suites of TLS 1.3 are not configurable"))
+	}
+	if c.Debug.Enabled && c.Debug.Token == "" {
+		errs = append(errs, errors.New("invalid debug.token: the debug endpoints require a token"))
+	}
+	if c.StreamHeartbeatInterval == 0 {
+		errs = append(errs, errors.New("invalid stream.heartbeat_interval: must be a positive duration"))
+	}
+	if c.WebhookMaxBackoff < c.WebhookBackoff {
+		errs = append(errs, errors.New("invalid webhooks.max_backoff: must not be less than webhooks.backoff"))
+	}
+	if c.Socket == "" && c.GRPCSocket == "" && c.GRPCAddr != "" && c.Addr == c.GRPCAddr {
+		errs = append(errs, errors.New("invalid server.grpc_port: must differ from server.port"))
+	}
+	if c.OpsAddr != "" && ((c.Socket == "" && c.OpsAddr == c.Addr) || (c.GRPCSocket == "" && c.OpsAddr == c.GRPCAddr)) {
+		errs = append(errs, errors.New("invalid server.ops_port: must differ from server.port and server.grpc_port"))
+	}
+	if c.Socket != "" && c.Socket == c.GRPCSocket {
+		errs = append(errs, errors.New("invalid server.grpc_socket: must differ from server.socket"))
+	}
+
+	return errs
 }
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readFile reads a YAML (.yaml, .yml) or JSON (.json) configuration file, returning its settings by key.
+// Nested sections are flattened into dotted keys, e.g. "cache: {ttl: 10m}" into "cache.ttl".
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	values := make(map[string]string)
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		// decoded as nodes, to keep the settings as written, e.g. 0660 rather than 432
+		var doc yaml.Node
+		if err = yaml.Unmarshal(data, &doc); err == nil && len(doc.Content) > 0 {
+			err = flattenYAML("", doc.Content[0], values)
+		}
+	case ".json":
+		var doc map[string]any
+		dec := json.NewDecoder(bytes.NewReader(data))
+		// numbers are kept as written, e.g. 67108864 rather than 6.7108864e+07
+		dec.UseNumber()
+		if err = dec.Decode(&doc); err == nil {
+			err = flatten("", doc, values)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported configuration file %s: must be .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+func flattenYAML(prefix string, section *yaml.Node, values map[string]string) error {
+	if section.Kind == yaml.AliasNode {
+		section = section.Alias
+	}
+	if section.Kind != yaml.MappingNode {
+		if prefix == "" {
+			return errors.New("must be a mapping of settings")
+		}
+		return fmt.Errorf("%s: must be a mapping of settings", strings.TrimSuffix(prefix, "."))
+	}
+
+	for i := 0; i < len(section.Content); i += 2 {
+		key, v := prefix+section.Content[i].Value, section.Content[i+1]
+		if v.Kind == yaml.AliasNode {
+			v = v.Alias
+		}
+		switch {
+		case v.Kind == yaml.MappingNode:
+			if err := flattenYAML(key+".", v, values); err != nil {
+				return err
+			}
+		case v.Kind == yaml.SequenceNode:
+			return fmt.Errorf("%s: lists are not supported", key)
+		case v.Tag == "!!null":
+			// "key:" without a value keeps the default
+		default:
+			values[key] = v.Value
+		}
+	}
+	return nil
+}
+
+func flatten(prefix string, section map[string]any, values map[string]string) error {
+	for k, v := range section {
+		key := prefix + k
+		switch v := v.(type) {
+		case map[string]any:
+			if err := flatten(key+".", v, values); err != nil {
+				return err
+			}
+		case []any:
+			return fmt.Errorf("%s: lists are not supported", key)
+		case nil:
+			// "key:" without a value keeps the default
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+// Print writes the effective configuration to w as a YAML configuration file, with the secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, o := range c.options() {
+		v := o.value.String()
+		if r, ok := o.value.(redacter); ok {
+			v = r.Redacted()
+		}
+
+		section := root
+		keys := strings.Split(o.key, ".")
+		for _, k := range keys[:len(keys)-1] {
+			section = subsection(section, k)
+		}
+		section.Content = append(section.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: keys[len(keys)-1]},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: v},
+		)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// subsection returns the section named key of the mapping section, adding it when missing.
+func subsection(section *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(section.Content); i += 2 {
+		if section.Content[i].Value == key {
+			return section.Content[i+1]
+		}
+	}
+	sub := &yaml.Node{Kind: yaml.MappingNode}
+	section.Content = append(section.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, sub)
+	return sub
+}
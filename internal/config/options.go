@@ -0,0 +1,394 @@
+package config
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/pkg/client"
+)
+
+// option is a setting of the configuration, read from the file, the environment and the flags.
+type option struct {
+	// key is the key in the configuration file, with the keys of the nested sections separated by dots.
+	key   string
+	env   string
+	usage string
+	value value
+}
+
+// value sets a field of the configuration from its text form, validating it.
+type value interface {
+	Set(s string) error
+	String() string
+}
+
+// redacter is implemented by the values that can hold secrets, see Config.Print.
+type redacter interface {
+	Redacted() string
+}
+
+// flag is the name of the command line flag of the option: its key with dashes, e.g. -cache-max-entries.
+func (o option) flag() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(o.key)
+}
+
+// options lists the settings of c, in the order of the configuration file.
+func (c *Config) options() []option {
+	opts := []option{
+		{"server.port", "PORT", "the port of the http server", portValue{&c.Addr}},
+		{"server.grpc_port", "GRPC_PORT", "the port of the gRPC server, which is disabled when neither it nor server.grpc_socket is set", optionalPortValue{portValue{&c.GRPCAddr}}},
+		{"server.ops_port", "OPS_PORT", "the port of the ops endpoints, e.g. /health, instead of the port of the http server", optionalPortValue{portValue{&c.OpsAddr}}},
+		{"server.socket", "SOCKET", "the unix socket of the http server, instead of its port", stringValue{&c.Socket}},
+		{"server.grpc_socket", "GRPC_SOCKET", "the unix socket of the gRPC server, instead of its port", stringValue{&c.GRPCSocket}},
+		{"server.socket_mode", "SOCKET_MODE", "the permissions of the unix sockets, e.g. 0660", fileModeValue{&c.SocketMode}},
+		{"server.graceful_restart", "GRACEFUL_RESTART", "re-execute the process on SIGUSR2, passing on the listeners without refusing connections", boolValue{&c.GracefulRestart}},
+		{"server.shutdown_timeout", "SHUTDOWN_TIMEOUT", "how long the requests in flight and the background work get to complete at shutdown", durationValue{&c.ShutdownTimeout}},
+		{"server.read_header_timeout", "READ_HEADER_TIMEOUT", "how long reading the headers of a request may take, 0 for no limit", durationValue{&c.ReadHeaderTimeout}},
+		{"server.read_timeout", "READ_TIMEOUT", "how long reading a whole request may take, 0 for no limit", durationValue{&c.ReadTimeout}},
+		{"server.write_timeout", "WRITE_TIMEOUT", "how long writing a response may take, streams excepted, 0 for no limit", durationValue{&c.WriteTimeout}},
+		{"server.idle_timeout", "IDLE_TIMEOUT", "how long an idle keep-alive connection is kept open, 0 for the read timeout", durationValue{&c.IdleTimeout}},
+		{"server.max_header_bytes", "MAX_HEADER_BYTES", "the maximum size of the headers of a request, 0 for the default of net/http (1MB)", nonNegativeIntValue{&c.MaxHeaderBytes}},
+		{"server.max_body_bytes", "MAX_BODY_BYTES", "the maximum size of the body of a request, 0 for no limit", nonNegativeIntValue{&c.MaxBodyBytes}},
+		{"server.handler_timeout", "HANDLER_TIMEOUT", "how long a request may take to be handled, 0 for no limit", durationValue{&c.HandlerTimeout}},
+		{"server.translated_handler_timeout", "TRANSLATED_HANDLER_TIMEOUT", "how long a request translating, or looking up, many pokemon may take, 0 for no limit", durationValue{&c.TranslatedHandlerTimeout}},
+
+		{"tls.cert_file", "TLS_CERT_FILE", "the PEM certificate file of the servers, chain included, enabling TLS", stringValue{&c.TLS.CertFile}},
+		{"tls.key_file", "TLS_KEY_FILE", "the PEM private key file of the certificate", stringValue{&c.TLS.KeyFile}},
+		{"tls.reload_interval", "TLS_RELOAD_INTERVAL", "how often the certificate files are checked for changes", durationValue{&c.TLS.ReloadInterval}},
+		{"tls.min_version", "TLS_MIN_VERSION", "the minimum TLS version: 1.2 or 1.3", tlsVersionValue{&c.TLS.MinVersion}},
+		{"tls.cipher_suites", "TLS_CIPHER_SUITES", "the comma separated cipher suites of TLS 1.2, the Go defaults when empty", cipherSuitesValue{&c.TLS.CipherSuites}},
+		{"tls.client_ca_file", "TLS_CLIENT_CA_FILE", "the PEM file of the CAs of the client certificates, enabling mutual TLS", stringValue{&c.TLS.ClientCAFile}},
+		{"tls.client_cert_optional", "TLS_CLIENT_CERT_OPTIONAL", "accept the clients without a certificate with mutual TLS", boolValue{&c.TLS.ClientCertOptional}},
+
+		{"admin.token", "ADMIN_TOKEN", "the bearer token of the admin endpoints, disabled when empty", secretValue{&c.AdminToken}},
+
+		{"debug.enabled", "DEBUG_ENABLED", "serve the pprof profiles and the runtime stats with the ops endpoints", boolValue{&c.Debug.Enabled}},
+		{"debug.token", "DEBUG_TOKEN", "the bearer token of the debug endpoints", secretValue{&c.Debug.Token}},
+
+		{"log.level", "LOG_LEVEL", "the minimum level of the logs: debug, info, warn or error", levelValue{&c.LogLevel}},
+		{"log.format", "LOG_FORMAT", "the format of the logs: text or json", choiceValue{&c.LogFormat, []string{"text", "json"}}},
+
+		{"upstreams.pokeapi.url", "POKEMON_API_URL", "the base url of the PokeAPI", urlValue{&c.PokemonAPIURL}},
+	}
+	opts = append(opts, clientOptions("upstreams.pokeapi", "POKEMON_API", "the PokeAPI", &c.PokemonAPIClient)...)
+	opts = append(opts, option{"upstreams.translation.url", "TRANSLATION_API_URL", "the base url of the funtranslations API", urlValue{&c.TranslationAPIURL}})
+	opts = append(opts, clientOptions("upstreams.translation", "TRANSLATION_API", "the funtranslations API", &c.TranslationAPIClient)...)
+	opts = append(opts, clientOptions("upstreams.webhooks", "WEBHOOK", "the webhooks", &c.WebhookClient)...)
+
+	return append(opts, []option{
+		{"batch.max_size", "MAX_BATCH_SIZE", "the maximum number of names accepted by the batch endpoints", intValue{&c.MaxBatchSize}},
+		{"batch.concurrency", "BATCH_CONCURRENCY", "the maximum number of concurrent upstream lookups per batch", intValue{&c.BatchConcurrency}},
+		{"listing.max_page_size", "MAX_PAGE_SIZE", "the maximum (and default) page size of the listing endpoints", intValue{&c.MaxPageSize}},
+		{"translation.budget", "TRANSLATION_BUDGET", "the maximum number of descriptions translated for a single batch or page", intValue{&c.TranslationBudget}},
+
+		{"stream.max_names", "STREAM_MAX_NAMES", "the maximum number of names of a translation stream", intValue{&c.StreamMaxNames}},
+		{"stream.heartbeat_interval", "STREAM_HEARTBEAT_INTERVAL", "how often a heartbeat is sent on idle translation streams", durationValue{&c.StreamHeartbeatInterval}},
+
+		{"jobs.workers", "JOB_WORKERS", "the number of translation jobs run at the same time", intValue{&c.JobWorkers}},
+		{"jobs.max_queued", "JOB_MAX_QUEUED", "the maximum number of translation jobs waiting for a worker", intValue{&c.JobMaxQueued}},
+		{"jobs.max_names", "JOB_MAX_NAMES", "the maximum number of names of a translation job", intValue{&c.JobMaxNames}},
+		{"jobs.ttl", "JOB_TTL", "how long finished translation jobs can be fetched", durationValue{&c.JobTTL}},
+
+		{"webhooks.max_attempts", "WEBHOOK_MAX_ATTEMPTS", "the number of attempts to deliver a webhook event", intValue{&c.WebhookMaxAttempts}},
+		{"webhooks.backoff", "WEBHOOK_BACKOFF", "the delay before the first retry of a webhook delivery", durationValue{&c.WebhookBackoff}},
+		{"webhooks.max_backoff", "WEBHOOK_MAX_BACKOFF", "the maximum delay between the retries of a webhook delivery", durationValue{&c.WebhookMaxBackoff}},
+		{"webhooks.max_dead_letters", "WEBHOOK_MAX_DEAD_LETTERS", "the number of failed webhook deliveries kept", intValue{&c.WebhookMaxDeadLetters}},
+		{"webhooks.max_subscriptions", "WEBHOOK_MAX_SUBSCRIPTIONS", "the maximum number of webhooks registered", intValue{&c.WebhookMaxSubscriptions}},
+		{"webhooks.workers", "WEBHOOK_WORKERS", "the number of webhook deliveries made at the same time", intValue{&c.WebhookWorkers}},
+		{"webhooks.max_queued", "WEBHOOK_MAX_QUEUED", "the maximum number of webhook deliveries waiting for a worker", intValue{&c.WebhookMaxQueued}},
+		{"webhooks.allow_private_urls", "WEBHOOK_ALLOW_PRIVATE_URLS", "accept the webhooks resolving to loopback, private or link-local addresses", boolValue{&c.WebhookAllowPrivateURLs}},
+
+		{"search.max_results", "MAX_SEARCH_RESULTS", "the maximum number of results of the search endpoint", intValue{&c.MaxSearchResults}},
+		{"search.max_suggestions", "MAX_SUGGESTIONS", "the maximum number of suggestions of not found errors", intValue{&c.MaxSuggestions}},
+		{"search.name_index_refresh_interval", "NAME_INDEX_REFRESH_INTERVAL", "how often the search index is refreshed, 0 disables it", durationValue{&c.NameIndexRefreshInterval}},
+
+		{"cache.ttl", "CACHE_TTL", "how long PokeAPI lookups are cached, 0 disables caching", durationValue{&c.CacheTTL}},
+		{"cache.max_entries", "CACHE_MAX_ENTRIES", "the maximum number of entries of each cache", intValue{&c.CacheMaxEntries}},
+		{"cache.type_ttl", "TYPE_CACHE_TTL", "how long type charts are cached", durationValue{&c.TypeCacheTTL}},
+		{"cache.sprite_dir", "SPRITE_CACHE_DIR", "the directory where sprites are cached", stringValue{&c.SpriteCacheDir}},
+		{"cache.sprite_max_bytes", "SPRITE_CACHE_MAX_BYTES", "the maximum size of the sprite cache", intValue{&c.SpriteCacheMaxBytes}},
+
+		{"featured.daily_timezone", "DAILY_TIMEZONE", "the IANA timezone in which the Pokemon of the day changes", locationValue{&c.DailyLocation}},
+		{"featured.daily_seed", "DAILY_SEED", "the seed of the Pokemon of the day", stringValue{&c.DailySeed}},
+		{"featured.max_attempts", "FEATURED_MAX_ATTEMPTS", "the maximum number of Pokemon tried by the random and daily endpoints", intValue{&c.FeaturedMaxAttempts}},
+
+		{"graphql.max_depth", "GRAPHQL_MAX_DEPTH", "the maximum nesting of fields of a GraphQL query", intValue{&c.GraphQLMaxDepth}},
+		{"graphql.max_complexity", "GRAPHQL_MAX_COMPLEXITY", "the maximum cost of a GraphQL query", intValue{&c.GraphQLMaxComplexity}},
+		{"graphql.introspection", "GRAPHQL_INTROSPECTION", "allow GraphQL introspection queries", boolValue{&c.GraphQLIntrospection}},
+	}...)
+}
+
+// clientOptions lists the settings of the http client of an upstream.
+func clientOptions(key, env, upstream string, c *client.Config) []option {
+	return []option{
+		{key + ".timeout", env + "_TIMEOUT", "the timeout of the requests to " + upstream, durationValue{&c.Timeout}},
+		{key + ".dial_timeout", env + "_DIAL_TIMEOUT", "the timeout of the connections to " + upstream, durationValue{&c.DialTimeout}},
+		{key + ".tls_handshake_timeout", env + "_TLS_HANDSHAKE_TIMEOUT", "the timeout of the TLS handshakes with " + upstream, durationValue{&c.TLSHandshakeTimeout}},
+		{key + ".response_header_timeout", env + "_RESPONSE_HEADER_TIMEOUT", "the timeout waiting for the response headers of " + upstream, durationValue{&c.ResponseHeaderTimeout}},
+		{key + ".idle_conn_timeout", env + "_IDLE_CONN_TIMEOUT", "how long idle connections to " + upstream + " are kept", durationValue{&c.IdleConnTimeout}},
+		{key + ".max_idle_conns", env + "_MAX_IDLE_CONNS", "the maximum number of idle connections to " + upstream, intValue{&c.MaxIdleConns}},
+		{key + ".max_idle_conns_per_host", env + "_MAX_IDLE_CONNS_PER_HOST", "the maximum number of idle connections to a host of " + upstream, intValue{&c.MaxIdleConnsPerHost}},
+	}
+}
+
+// flagValue records the value of a flag, see Load.
+type flagValue struct {
+	name   string
+	def    string
+	isBool bool
+	flags  map[string]string
+}
+
+func (v *flagValue) Set(s string) error {
+	v.flags[v.name] = s
+	return nil
+}
+
+func (v *flagValue) String() string {
+	return v.def
+}
+
+// IsBoolFlag allows boolean flags without a value, e.g. -graphql-introspection.
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+// intValue is a positive integer.
+type intValue struct{ p *int }
+
+func (v intValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return errors.New("must be a positive integer")
+	}
+	*v.p = n
+	return nil
+}
+
+func (v intValue) String() string { return strconv.Itoa(*v.p) }
+
+// nonNegativeIntValue is a positive integer or zero.
+type nonNegativeIntValue struct{ p *int }
+
+func (v nonNegativeIntValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return errors.New("must be a non-negative integer")
+	}
+	*v.p = n
+	return nil
+}
+
+func (v nonNegativeIntValue) String() string { return strconv.Itoa(*v.p) }
+
+// durationValue is a non-negative duration, e.g. "24h".
+type durationValue struct{ p *time.Duration }
+
+func (v durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return errors.New("must be a non-negative duration")
+	}
+	*v.p = d
+	return nil
+}
+
+func (v durationValue) String() string { return v.p.String() }
+
+// boolValue is a boolean, e.g. "true" or "0".
+type boolValue struct{ p *bool }
+
+func (v boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return errors.New("must be a boolean")
+	}
+	*v.p = b
+	return nil
+}
+
+func (v boolValue) String() string { return strconv.FormatBool(*v.p) }
+
+type stringValue struct{ p *string }
+
+func (v stringValue) Set(s string) error {
+	*v.p = s
+	return nil
+}
+
+func (v stringValue) String() string { return *v.p }
+
+// secretValue is a string never printed.
+type secretValue struct{ p *string }
+
+func (v secretValue) Set(s string) error {
+	*v.p = s
+	return nil
+}
+
+func (v secretValue) String() string { return *v.p }
+
+func (v secretValue) Redacted() string {
+	if *v.p == "" {
+		return ""
+	}
+	return "REDACTED"
+}
+
+// urlValue is a url, whose password is a secret. It is checked by Config.validate, as it can be missing.
+type urlValue struct{ p *string }
+
+func (v urlValue) Set(s string) error {
+	*v.p = s
+	return nil
+}
+
+func (v urlValue) String() string { return *v.p }
+
+func (v urlValue) Redacted() string {
+	u, err := url.Parse(*v.p)
+	if err != nil {
+		return "REDACTED"
+	}
+	return u.Redacted()
+}
+
+// portValue is a tcp port, setting the address listening on it on all interfaces.
+type portValue struct{ addr *string }
+
+func (v portValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 65535 {
+		return errors.New("must be a port number")
+	}
+	*v.addr = ":" + s
+	return nil
+}
+
+func (v portValue) String() string { return strings.TrimPrefix(*v.addr, ":") }
+
+// optionalPortValue is a portValue that can be empty, for no address.
+type optionalPortValue struct{ portValue }
+
+func (v optionalPortValue) Set(s string) error {
+	if s == "" {
+		*v.addr = ""
+		return nil
+	}
+	return v.portValue.Set(s)
+}
+
+// levelValue is a log level.
+type levelValue struct{ p *slog.Level }
+
+func (v levelValue) Set(s string) error {
+	if err := v.p.UnmarshalText([]byte(s)); err != nil {
+		return errors.New("must be one of: debug, info, warn, error")
+	}
+	return nil
+}
+
+func (v levelValue) String() string { return strings.ToLower(v.p.String()) }
+
+// choiceValue is one of choices.
+type choiceValue struct {
+	p       *string
+	choices []string
+}
+
+func (v choiceValue) Set(s string) error {
+	if !slices.Contains(v.choices, s) {
+		return fmt.Errorf("must be one of: %s", strings.Join(v.choices, ", "))
+	}
+	*v.p = s
+	return nil
+}
+
+func (v choiceValue) String() string { return *v.p }
+
+// fileModeValue is octal file permissions, e.g. "0660".
+type fileModeValue struct{ p *os.FileMode }
+
+func (v fileModeValue) Set(s string) error {
+	n, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || n > 0o777 {
+		return errors.New("must be octal file permissions")
+	}
+	*v.p = os.FileMode(n)
+	return nil
+}
+
+func (v fileModeValue) String() string { return fmt.Sprintf("%04o", uint32(*v.p)) }
+
+// locationValue is an IANA timezone, e.g. "Europe/Rome".
+type locationValue struct{ p **time.Location }
+
+func (v locationValue) Set(s string) error {
+	l, err := time.LoadLocation(s)
+	if err != nil {
+		return errors.New("must be an IANA timezone")
+	}
+	*v.p = l
+	return nil
+}
+
+func (v locationValue) String() string { return (*v.p).String() }
+
+// tlsVersionValue is a TLS version, e.g. "1.3".
+type tlsVersionValue struct{ p *uint16 }
+
+var tlsVersions = map[string]uint16{"1.2": tls.VersionTLS12, "1.3": tls.VersionTLS13}
+
+func (v tlsVersionValue) Set(s string) error {
+	version, ok := tlsVersions[s]
+	if !ok {
+		return errors.New("must be one of: 1.2, 1.3")
+	}
+	*v.p = version
+	return nil
+}
+
+func (v tlsVersionValue) String() string {
+	for name, version := range tlsVersions {
+		if version == *v.p {
+			return name
+		}
+	}
+	return ""
+}
+
+// cipherSuitesValue is a comma separated list of secure cipher suites, e.g. "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256".
+type cipherSuitesValue struct{ p *[]uint16 }
+
+func (v cipherSuitesValue) Set(s string) error {
+	var ids []uint16
+	for name := range strings.SplitSeq(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		i := slices.IndexFunc(tls.CipherSuites(), func(c *tls.CipherSuite) bool { return c.Name == name })
+		if i < 0 {
+			return fmt.Errorf("unknown or insecure cipher suite %s", name)
+		}
+		ids = append(ids, tls.CipherSuites()[i].ID)
+	}
+	*v.p = ids
+	return nil
+}
+
+func (v cipherSuitesValue) String() string {
+	names := make([]string, len(*v.p))
+	for i, id := range *v.p {
+		names[i] = tls.CipherSuiteName(id)
+	}
+	return strings.Join(names, ",")
+}
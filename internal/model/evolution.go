@@ -0,0 +1,32 @@
+package model
+
+// EvolutionChain is the evolution tree of a family of pokemon, starting from its base form.
+type EvolutionChain struct {
+	ID    int
+	Chain EvolutionNode
+}
+
+// EvolutionNode is a species of the chain. Conditions describe the alternative ways to
+// evolve into it from its parent, and is empty for the base form.
+type EvolutionNode struct {
+	Name       string
+	Conditions []EvolutionCondition
+	EvolvesTo  []EvolutionNode
+}
+
+// EvolutionCondition describes how an evolution is triggered. Zero values mean "no requirement".
+type EvolutionCondition struct {
+	Trigger            string
+	MinLevel           int
+	Item               string
+	HeldItem           string
+	MinHappiness       int
+	MinAffection       int
+	MinBeauty          int
+	TimeOfDay          string
+	KnownMove          string
+	KnownMoveType      string
+	Location           string
+	TradeSpecies       string
+	NeedsOverworldRain bool
+}
@@ -1,8 +1,38 @@
 package model
 
 type Pokemon struct {
+	ID          int
 	Name        string
 	Description string
-	Habitat     string
-	IsLegendary *bool
+	// Descriptions holds the first description of the species in each language, by language code (e.g. "fr").
+	Descriptions map[string]string
+	Habitat      string
+	IsLegendary  *bool
+
+	Types     []string
+	Stats     []Stat
+	Abilities []Ability
+	Height    int
+	Weight    int
+
+	// Unavailable lists the requested sections of data that could not be loaded.
+	Unavailable []string
+}
+
+type Stat struct {
+	Name     string
+	BaseStat int
+}
+
+type Ability struct {
+	Name     string
+	IsHidden bool
+}
+
+// NamePage is a page of resource names (pokemon, habitats...) out of a listing of Total elements.
+type NamePage struct {
+	Names  []string
+	Offset int
+	Limit  int
+	Total  int
 }
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// Sprite is an image of a pokemon.
+type Sprite struct {
+	ContentType string
+	ModTime     time.Time
+	Data        []byte
+}
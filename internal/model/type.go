@@ -0,0 +1,29 @@
+package model
+
+// TypeRelations holds the damage relations of an elemental type with the other types.
+type TypeRelations struct {
+	Name string
+
+	DoubleDamageTo []string
+	HalfDamageTo   []string
+	NoDamageTo     []string
+
+	DoubleDamageFrom []string
+	HalfDamageFrom   []string
+	NoDamageFrom     []string
+}
+
+// TypeMultiplier is the damage multiplier of an attacking type.
+type TypeMultiplier struct {
+	Type       string
+	Multiplier float64
+}
+
+// DefenseMatchup summarizes how attacking types fare against a pokemon, combining all of its types.
+type DefenseMatchup struct {
+	Name        string
+	Types       []string
+	Weaknesses  []TypeMultiplier
+	Resistances []TypeMultiplier
+	Immunities  []string
+}
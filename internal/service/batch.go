@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+// PokemonResult is the outcome of a single lookup within a batch.
+type PokemonResult struct {
+	Name    string
+	Pokemon model.Pokemon
+	Err     error
+}
+
+// PokemonBatchService fetches many pokemon with getter, running at most concurrency lookups at a time.
+// Results keep the order of names and failures are reported per item instead of failing the whole batch.
+func PokemonBatchService(
+	getter func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error),
+	concurrency int,
+) func(ctx context.Context, names []string, opts PokemonOptions) []PokemonResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return func(ctx context.Context, names []string, opts PokemonOptions) []PokemonResult {
+		results := make([]PokemonResult, len(names))
+		sem := make(chan struct{}, concurrency)
+
+		var wg sync.WaitGroup
+		for i, name := range names {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					results[i] = PokemonResult{Name: name, Err: ctx.Err()}
+					return
+				}
+
+				p, err := getter(ctx, name, opts)
+				results[i] = PokemonResult{Name: name, Pokemon: p, Err: err}
+			}()
+		}
+		wg.Wait()
+
+		return results
+	}
+}
+
+// UnavailableTranslation is reported in model.Pokemon.Unavailable when the description was left untranslated
+// because the translation budget of the batch was exhausted.
+const UnavailableTranslation = "translation"
+
+// PokemonBatchTranslatorService translates the descriptions of the pokemon fetched by getBatch, running at most
+// concurrency translations at a time. Only the first budget pokemon of the batch are translated: the translation
+// API is heavily rate limited, so the others keep their original description and list UnavailableTranslation.
+// The translated batches and the expanded pages of habitat pokemon share it, so a budget under the maximum batch
+// size leaves the end of the larger batches untranslated.
+func PokemonBatchTranslatorService(
+	getBatch func(ctx context.Context, names []string, opts PokemonOptions) []PokemonResult,
+	translator Translator,
+	concurrency int,
+	budget int,
+) func(ctx context.Context, names []string, opts PokemonOptions) []PokemonResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	translatorService := pokemonTranslatorService(translator)
+	return func(ctx context.Context, names []string, opts PokemonOptions) []PokemonResult {
+		results := getBatch(ctx, names, opts)
+		if !opts.NeedsField(FieldDescription) {
+			return results
+		}
+
+		sem := make(chan struct{}, concurrency)
+
+		var wg sync.WaitGroup
+		remaining := budget
+		for i := range results {
+			if results[i].Err != nil {
+				continue
+			}
+			if remaining < 1 {
+				p := &results[i].Pokemon
+				p.Unavailable = append(p.Unavailable, UnavailableTranslation)
+				continue
+			}
+			remaining--
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					return
+				}
+
+				results[i].Pokemon = translatorService(ctx, results[i].Pokemon, opts)
+			}()
+		}
+		wg.Wait()
+
+		return results
+	}
+}
+
+// PokemonBatchNotifierService calls onFinish with the results of every batch got with getBatch, once they are all
+// known. onFinish must not block.
+func PokemonBatchNotifierService(
+	getBatch func(ctx context.Context, names []string, opts PokemonOptions) []PokemonResult,
+	onFinish func(results []PokemonResult),
+) func(ctx context.Context, names []string, opts PokemonOptions) []PokemonResult {
+	return func(ctx context.Context, names []string, opts PokemonOptions) []PokemonResult {
+		results := getBatch(ctx, names, opts)
+		onFinish(results)
+		return results
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPokemonBatchService(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mockGetter  func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error)
+		names       []string
+		expected    []PokemonResult
+		concurrency int
+	}{
+		{
+			name: "Success - All Pokemon Found In Order",
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+				return model.Pokemon{Name: name, IsLegendary: client.BoolPtr(false)}, nil
+			},
+			names:       []string{"pikachu", "raichu", "zubat"},
+			concurrency: 2,
+			expected: []PokemonResult{
+				{Name: "pikachu", Pokemon: model.Pokemon{Name: "pikachu", IsLegendary: client.BoolPtr(false)}},
+				{Name: "raichu", Pokemon: model.Pokemon{Name: "raichu", IsLegendary: client.BoolPtr(false)}},
+				{Name: "zubat", Pokemon: model.Pokemon{Name: "zubat", IsLegendary: client.BoolPtr(false)}},
+			},
+		},
+		{
+			name: "Partial Success - Errors Reported Per Item",
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+				if name == "missingno" {
+					return model.Pokemon{}, ErrNotFound
+				}
+				return model.Pokemon{Name: name}, nil
+			},
+			names:       []string{"pikachu", "missingno"},
+			concurrency: 1,
+			expected: []PokemonResult{
+				{Name: "pikachu", Pokemon: model.Pokemon{Name: "pikachu"}},
+				{Name: "missingno", Err: ErrNotFound},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := PokemonBatchService(tc.mockGetter, tc.concurrency)
+			result := service(context.Background(), tc.names, PokemonOptions{})
+
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestPokemonBatchServiceBoundedConcurrency(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	getter := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return model.Pokemon{Name: name}, nil
+	}
+
+	service := PokemonBatchService(getter, 2)
+	result := service(context.Background(), []string{"a", "b", "c", "d", "e", "f"}, PokemonOptions{})
+
+	require.Len(t, result, 6)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(2))
+}
+
+func TestPokemonBatchTranslatorService(t *testing.T) {
+	getBatch := func(ctx context.Context, names []string, opts PokemonOptions) []PokemonResult {
+		results := make([]PokemonResult, 0, len(names))
+		for _, n := range names {
+			if n == "missingno" {
+				results = append(results, PokemonResult{Name: n, Err: ErrNotFound})
+				continue
+			}
+			results = append(results, PokemonResult{Name: n, Pokemon: model.Pokemon{Name: n, Description: n + " description"}})
+		}
+		return results
+	}
+	var translations atomic.Int32
+	translator := func(ctx context.Context, style TranslationStyle, text string) (string, error) {
+		translations.Add(1)
+		return "translated " + text, nil
+	}
+
+	service := PokemonBatchTranslatorService(getBatch, translator, 2, 2)
+	result := service(context.Background(), []string{"pikachu", "missingno", "zubat", "onix"}, PokemonOptions{})
+
+	assert.Equal(t, []PokemonResult{
+		{Name: "pikachu", Pokemon: model.Pokemon{Name: "pikachu", Description: "translated pikachu description"}},
+		{Name: "missingno", Err: ErrNotFound},
+		{Name: "zubat", Pokemon: model.Pokemon{Name: "zubat", Description: "translated zubat description"}},
+		{Name: "onix", Pokemon: model.Pokemon{
+			Name:        "onix",
+			Description: "onix description",
+			Unavailable: []string{UnavailableTranslation},
+		}},
+	}, result)
+	assert.Equal(t, int32(2), translations.Load())
+}
+
+func TestPokemonBatchNotifierService(t *testing.T) {
+	getBatch := func(ctx context.Context, names []string, opts PokemonOptions) []PokemonResult {
+		return []PokemonResult{{Name: names[0], Pokemon: model.Pokemon{Name: names[0]}}}
+	}
+	var notified [][]PokemonResult
+	service := PokemonBatchNotifierService(getBatch, func(results []PokemonResult) {
+		notified = append(notified, results)
+	})
+
+	results := service(context.Background(), []string{"pikachu"}, PokemonOptions{})
+
+	assert.Equal(t, [][]PokemonResult{results}, notified)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/pkg/cache"
+)
+
+// CachedLookup memoizes successful lookups by key. Errors are never cached.
+func CachedLookup[V any](
+	lookup func(ctx context.Context, key string) (V, error),
+	c *cache.Cache[string, V],
+) func(ctx context.Context, key string) (V, error) {
+	return func(ctx context.Context, key string) (V, error) {
+		if v, ok := c.Get(key); ok {
+			return v, nil
+		}
+
+		v, err := lookup(ctx, key)
+		if err != nil {
+			return v, err
+		}
+
+		c.Set(key, v)
+		return v, nil
+	}
+}
+
+// PokemonCacheService memoizes pokemon lookups. The sections included by the options are part of
+// the cache key, and pokemon with unavailable sections are not cached so that they are retried.
+func PokemonCacheService(getter PokemonInfoGetter, c *cache.Cache[string, model.Pokemon]) PokemonInfoGetter {
+	return func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		key := name + "|" + strings.Join(opts.Include.Names(), ",")
+		if p, ok := c.Get(key); ok {
+			return p, nil
+		}
+
+		p, err := getter(ctx, name, opts)
+		if err != nil {
+			return p, err
+		}
+
+		if len(p.Unavailable) == 0 {
+			c.Set(key, p)
+		}
+		return p, nil
+	}
+}
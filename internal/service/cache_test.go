@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedLookup(t *testing.T) {
+	calls := 0
+	lookup := func(ctx context.Context, key string) (model.EvolutionChain, error) {
+		calls++
+		if key == "missingno" {
+			return model.EvolutionChain{}, ErrNotFound
+		}
+		return model.EvolutionChain{ID: 1, Chain: model.EvolutionNode{Name: key}}, nil
+	}
+	cached := CachedLookup(lookup, cache.New[string, model.EvolutionChain](time.Minute, 10))
+
+	for range 3 {
+		chain, err := cached(context.Background(), "bulbasaur")
+		assert.NoError(t, err)
+		assert.Equal(t, "bulbasaur", chain.Chain.Name)
+	}
+	assert.Equal(t, 1, calls)
+
+	for range 2 {
+		_, err := cached(context.Background(), "missingno")
+		assert.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, 3, calls, "errors must not be cached")
+}
+
+func TestPokemonCacheService(t *testing.T) {
+	calls := 0
+	getter := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		calls++
+		p := model.Pokemon{Name: name}
+		if opts.Include.Stats {
+			p.Stats = []model.Stat{{Name: "hp", BaseStat: 35}}
+		}
+		if name == "partial" {
+			p.Unavailable = []string{IncludeStats}
+		}
+		return p, nil
+	}
+	cached := PokemonCacheService(getter, cache.New[string, model.Pokemon](time.Minute, 10))
+	withStats := PokemonOptions{Include: Include{Stats: true}}
+
+	p, _ := cached(context.Background(), "pikachu", PokemonOptions{})
+	assert.Empty(t, p.Stats)
+	p, _ = cached(context.Background(), "pikachu", withStats)
+	assert.NotEmpty(t, p.Stats, "include must be part of the cache key")
+	cached(context.Background(), "pikachu", PokemonOptions{})
+	cached(context.Background(), "pikachu", withStats)
+	assert.Equal(t, 2, calls)
+
+	cached(context.Background(), "partial", withStats)
+	cached(context.Background(), "partial", withStats)
+	assert.Equal(t, 4, calls, "partial pokemon must not be cached")
+}
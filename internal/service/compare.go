@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+type StatDelta struct {
+	Name  string
+	A     int
+	B     int
+	Delta int
+}
+
+// Comparison is the side by side comparison of two pokemon. When one of them could not be
+// loaded only A and B are set, with the error of the missing one.
+type Comparison struct {
+	A PokemonResult
+	B PokemonResult
+
+	StatDeltas  []StatDelta
+	SharedTypes []string
+	TypesOnlyA  []string
+	TypesOnlyB  []string
+
+	// AVersusB is the multiplier of each type of A attacking B, and BVersusA the opposite.
+	AVersusB []model.TypeMultiplier
+	BVersusA []model.TypeMultiplier
+}
+
+// Complete reports whether both pokemon were loaded and compared.
+func (c Comparison) Complete() bool {
+	return c.A.Err == nil && c.B.Err == nil
+}
+
+// PokemonCompareService loads two pokemon concurrently with their stats and types and compares them.
+// An error is returned only when neither pokemon could be loaded or the type charts are unavailable.
+func PokemonCompareService(
+	getPokemon func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error),
+	getType TypeGetter,
+) func(ctx context.Context, a, b string) (Comparison, error) {
+	return func(ctx context.Context, a, b string) (Comparison, error) {
+		opts := PokemonOptions{Include: Include{Stats: true, Types: true}}
+
+		var c Comparison
+		var wg sync.WaitGroup
+		for _, side := range []struct {
+			name   string
+			result *PokemonResult
+		}{{a, &c.A}, {b, &c.B}} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p, err := getPokemon(ctx, side.name, opts)
+				if err == nil && len(p.Unavailable) > 0 {
+					err = ErrServiceUnavailable
+				}
+				*side.result = PokemonResult{Name: side.name, Pokemon: p, Err: err}
+			}()
+		}
+		wg.Wait()
+
+		if c.A.Err != nil && c.B.Err != nil {
+			return Comparison{}, c.A.Err
+		}
+		if !c.Complete() {
+			return c, nil
+		}
+
+		pa, pb := c.A.Pokemon, c.B.Pokemon
+		c.StatDeltas = statDeltas(pa.Stats, pb.Stats)
+		for _, t := range pa.Types {
+			if slices.Contains(pb.Types, t) {
+				c.SharedTypes = append(c.SharedTypes, t)
+			} else {
+				c.TypesOnlyA = append(c.TypesOnlyA, t)
+			}
+		}
+		for _, t := range pb.Types {
+			if !slices.Contains(pa.Types, t) {
+				c.TypesOnlyB = append(c.TypesOnlyB, t)
+			}
+		}
+
+		relationsA, err := typeRelations(ctx, getType, pa.Types)
+		if err != nil {
+			return Comparison{}, err
+		}
+		relationsB, err := typeRelations(ctx, getType, pb.Types)
+		if err != nil {
+			return Comparison{}, err
+		}
+		c.AVersusB = attackMultipliers(pa.Types, relationsB)
+		c.BVersusA = attackMultipliers(pb.Types, relationsA)
+
+		return c, nil
+	}
+}
+
+func statDeltas(a, b []model.Stat) []StatDelta {
+	deltas := make([]StatDelta, 0, len(a))
+	for _, sa := range a {
+		d := StatDelta{Name: sa.Name, A: sa.BaseStat}
+		if i := slices.IndexFunc(b, func(sb model.Stat) bool { return sb.Name == sa.Name }); i >= 0 {
+			d.B = b[i].BaseStat
+		}
+		d.Delta = d.B - d.A
+		deltas = append(deltas, d)
+	}
+	return deltas
+}
+
+func typeRelations(ctx context.Context, getType TypeGetter, types []string) ([]model.TypeRelations, error) {
+	relations := make([]model.TypeRelations, 0, len(types))
+	for _, t := range types {
+		r, err := getType(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		relations = append(relations, r)
+	}
+	return relations, nil
+}
+
+func attackMultipliers(attackTypes []string, defender []model.TypeRelations) []model.TypeMultiplier {
+	multipliers := make([]model.TypeMultiplier, 0, len(attackTypes))
+	for _, t := range attackTypes {
+		multipliers = append(multipliers, model.TypeMultiplier{Type: t, Multiplier: DefenseMultiplier(t, defender)})
+	}
+	return multipliers
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPokemonCompareService(t *testing.T) {
+	getPokemon := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		if !opts.Include.Stats || !opts.Include.Types {
+			t.Fatal("stats and types must be requested")
+		}
+		switch name {
+		case "charizard":
+			return model.Pokemon{
+				Name:        "charizard",
+				Habitat:     "mountain",
+				IsLegendary: client.BoolPtr(false),
+				Types:       []string{"fire", "flying"},
+				Stats:       []model.Stat{{Name: "hp", BaseStat: 78}, {Name: "speed", BaseStat: 100}},
+			}, nil
+		case "moltres":
+			return model.Pokemon{
+				Name:        "moltres",
+				Habitat:     "rare",
+				IsLegendary: client.BoolPtr(true),
+				Types:       []string{"fire", "flying"},
+				Stats:       []model.Stat{{Name: "hp", BaseStat: 90}, {Name: "speed", BaseStat: 90}},
+			}, nil
+		case "ponyta":
+			return model.Pokemon{Name: "ponyta", Types: []string{"fire"}}, nil
+		}
+		return model.Pokemon{}, ErrNotFound
+	}
+	service := PokemonCompareService(getPokemon, mockTypeGetter)
+
+	t.Run("Success - Both Found", func(t *testing.T) {
+		c, err := service(context.Background(), "charizard", "moltres")
+
+		require.NoError(t, err)
+		assert.True(t, c.Complete())
+		assert.Equal(t, []StatDelta{{Name: "hp", A: 78, B: 90, Delta: 12}, {Name: "speed", A: 100, B: 90, Delta: -10}}, c.StatDeltas)
+		assert.Equal(t, []string{"fire", "flying"}, c.SharedTypes)
+		assert.Empty(t, c.TypesOnlyA)
+		assert.Equal(t, []model.TypeMultiplier{{Type: "fire", Multiplier: 0.5}, {Type: "flying", Multiplier: 1}}, c.AVersusB)
+	})
+
+	t.Run("Success - Different Types", func(t *testing.T) {
+		c, err := service(context.Background(), "ponyta", "charizard")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"fire"}, c.SharedTypes)
+		assert.Equal(t, []string{"flying"}, c.TypesOnlyB)
+		assert.Equal(t, []model.TypeMultiplier{{Type: "fire", Multiplier: 0.5}, {Type: "flying", Multiplier: 1}}, c.BVersusA)
+	})
+
+	t.Run("Partial - One Not Found", func(t *testing.T) {
+		c, err := service(context.Background(), "charizard", "missingno")
+
+		require.NoError(t, err)
+		assert.False(t, c.Complete())
+		assert.Equal(t, "charizard", c.A.Pokemon.Name)
+		assert.ErrorIs(t, c.B.Err, ErrNotFound)
+		assert.Empty(t, c.StatDeltas)
+	})
+
+	t.Run("Error - Both Not Found", func(t *testing.T) {
+		_, err := service(context.Background(), "missingno", "missingno")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+type EvolutionChainGetter func(ctx context.Context, name string) (model.EvolutionChain, error)
+
+func PokemonEvolutionService(getter EvolutionChainGetter) func(ctx context.Context, name string) (model.EvolutionChain, error) {
+	return func(ctx context.Context, name string) (model.EvolutionChain, error) {
+		name, err := NormalizeName(name)
+		if err != nil {
+			return model.EvolutionChain{}, err
+		}
+
+		chain, err := getter(ctx, name)
+		if err != nil {
+			return model.EvolutionChain{}, err
+		}
+
+		if chain.Chain.Name == "" {
+			return model.EvolutionChain{}, ErrMissingData
+		}
+
+		return chain, nil
+	}
+}
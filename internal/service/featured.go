@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+var ErrNoPokemonMatch = errors.New("no pokemon matches the filters")
+
+//go:embed data/legendary.txt
+var bundledLegendaries string
+
+// LegendarySpecies returns the names of the legendary species, up to the ninth generation.
+func LegendarySpecies() []string {
+	return strings.Fields(bundledLegendaries)
+}
+
+// RandomFilter restricts the pokemon picked by PokemonRandomService. Zero values match everything.
+type RandomFilter struct {
+	Habitat   string
+	Legendary *bool
+}
+
+func (f RandomFilter) matches(p model.Pokemon) bool {
+	return f.Legendary == nil || (p.IsLegendary != nil && *p.IsLegendary == *f.Legendary)
+}
+
+// candidate reports whether the pokemon name may match the filter, knowing the legendary species,
+// so that the others are not fetched.
+func (f RandomFilter) candidate(legendaries []string) func(name string) bool {
+	return func(name string) bool {
+		return f.Legendary == nil || slices.Contains(legendaries, name) == *f.Legendary
+	}
+}
+
+// candidatePool gives access to the names a featured pokemon is picked from, without loading all of them.
+type candidatePool struct {
+	size int
+	name func(ctx context.Context, i int) (string, error)
+}
+
+// PokemonRandomService picks a random pokemon among every species, or among the members of the habitat of the filter.
+// The legendary ones are picked among legendaries, the names of the legendary species, usually LegendarySpecies(),
+// and the others exclude them. Legendary status is still checked once a pokemon is fetched: at most maxAttempts
+// candidates are tried before giving up with ErrNoPokemonMatch.
+func PokemonRandomService(
+	getter func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error),
+	lister PokemonLister,
+	getMembers HabitatMembersGetter,
+	legendaries []string,
+	maxAttempts int,
+) func(ctx context.Context, filter RandomFilter, opts PokemonOptions) (model.Pokemon, error) {
+	return func(ctx context.Context, filter RandomFilter, opts PokemonOptions) (model.Pokemon, error) {
+		var (
+			pool candidatePool
+			err  error
+		)
+		switch {
+		case filter.Habitat != "":
+			pool, err = habitatPool(ctx, getMembers, filter.Habitat, filter.candidate(legendaries))
+		case filter.Legendary != nil && *filter.Legendary:
+			pool = namesPool(legendaries)
+		default:
+			pool, err = speciesPool(ctx, lister)
+		}
+		if err != nil {
+			return model.Pokemon{}, err
+		}
+		if pool.size == 0 {
+			return model.Pokemon{}, ErrNoPokemonMatch
+		}
+
+		order := rand.Perm(pool.size)
+		return firstMatch(ctx, getter, opts, pool, order, maxAttempts, filter.candidate(legendaries), filter.matches)
+	}
+}
+
+// PokemonDailyService picks the pokemon of the day of date in loc. The choice only depends on the day and seed,
+// so that every replica agrees on it. If the picked pokemon cannot be loaded the next ones are tried, up to maxAttempts.
+func PokemonDailyService(
+	getter func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error),
+	lister PokemonLister,
+	loc *time.Location,
+	seed string,
+	maxAttempts int,
+) func(ctx context.Context, date time.Time, opts PokemonOptions) (model.Pokemon, error) {
+	return func(ctx context.Context, date time.Time, opts PokemonOptions) (model.Pokemon, error) {
+		pool, err := speciesPool(ctx, lister)
+		if err != nil {
+			return model.Pokemon{}, err
+		}
+
+		h := fnv.New64a()
+		h.Write([]byte(seed + "/" + date.In(loc).Format(time.DateOnly)))
+		start := int(h.Sum64() % uint64(pool.size))
+
+		order := make([]int, 0, min(pool.size, maxAttempts))
+		for i := range cap(order) {
+			order = append(order, (start+i)%pool.size)
+		}
+		return firstMatch(ctx, getter, opts, pool, order, maxAttempts,
+			func(string) bool { return true },
+			func(model.Pokemon) bool { return true },
+		)
+	}
+}
+
+func speciesPool(ctx context.Context, lister PokemonLister) (candidatePool, error) {
+	page, err := lister(ctx, 1, 0)
+	if err != nil {
+		return candidatePool{}, err
+	}
+	if page.Total == 0 {
+		return candidatePool{}, ErrNoPokemonMatch
+	}
+
+	return candidatePool{
+		size: page.Total,
+		name: func(ctx context.Context, i int) (string, error) {
+			page, err := lister(ctx, 1, i)
+			if err != nil {
+				return "", err
+			}
+			if len(page.Names) == 0 {
+				return "", ErrNotFound
+			}
+			return page.Names[0], nil
+		},
+	}, nil
+}
+
+// habitatPool returns the members of habitat that are candidates.
+func habitatPool(ctx context.Context, getMembers HabitatMembersGetter, habitat string, candidate func(name string) bool) (candidatePool, error) {
+	habitat, err := NormalizeName(habitat)
+	if err != nil {
+		return candidatePool{}, ErrInvalidHabitat
+	}
+
+	members, err := getMembers(ctx, habitat)
+	if err != nil {
+		return candidatePool{}, err
+	}
+	return namesPool(slices.DeleteFunc(slices.Clone(members), func(name string) bool { return !candidate(name) })), nil
+}
+
+func namesPool(names []string) candidatePool {
+	return candidatePool{
+		size: len(names),
+		name: func(_ context.Context, i int) (string, error) {
+			return names[i], nil
+		},
+	}
+}
+
+// firstMatch returns the first pokemon of pool, looked up with opts in the given order of indexes, accepted by match. The names that
+// are not candidates are skipped without being fetched, and at most maxAttempts candidates are fetched.
+// Candidates that cannot be loaded are skipped, unless upstream is unavailable.
+func firstMatch(
+	ctx context.Context,
+	getter func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error),
+	opts PokemonOptions,
+	pool candidatePool,
+	order []int,
+	maxAttempts int,
+	candidate func(name string) bool,
+	match func(p model.Pokemon) bool,
+) (model.Pokemon, error) {
+	attempts := 0
+	for _, i := range order {
+		if attempts == maxAttempts {
+			break
+		}
+		name, err := pool.name(ctx, i)
+		if err == nil && !candidate(name) {
+			continue
+		}
+		attempts++
+		if err == nil {
+			var p model.Pokemon
+			p, err = getter(ctx, name, opts)
+			if err == nil && match(p) {
+				return p, nil
+			}
+		}
+		if ctx.Err() != nil {
+			return model.Pokemon{}, ctx.Err()
+		}
+		if errors.Is(err, ErrServiceUnavailable) {
+			return model.Pokemon{}, err
+		}
+	}
+
+	return model.Pokemon{}, ErrNoPokemonMatch
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var featuredSpecies = map[string]model.Pokemon{
+	"bulbasaur": {Name: "bulbasaur", Habitat: "grassland", IsLegendary: client.BoolPtr(false)},
+	"zubat":     {Name: "zubat", Habitat: "cave", IsLegendary: client.BoolPtr(false)},
+	"golbat":    {Name: "golbat", Habitat: "cave", IsLegendary: client.BoolPtr(false)},
+	"mewtwo":    {Name: "mewtwo", Habitat: "rare", IsLegendary: client.BoolPtr(true)},
+	"missingno": {Name: "missingno"},
+}
+
+var featuredNames = []string{"bulbasaur", "zubat", "golbat", "mewtwo", "missingno"}
+
+// featuredLegendaries also lists a legendary species unknown upstream.
+var featuredLegendaries = []string{"mewtwo", "lugia"}
+
+func featuredGetter(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+	p, ok := featuredSpecies[name]
+	if !ok || p.IsLegendary == nil {
+		return model.Pokemon{}, ErrMissingData
+	}
+	return p, nil
+}
+
+func featuredLister(ctx context.Context, limit, offset int) (model.NamePage, error) {
+	end := min(offset+limit, len(featuredNames))
+	return model.NamePage{Names: featuredNames[offset:end], Offset: offset, Limit: limit, Total: len(featuredNames)}, nil
+}
+
+func featuredMembers(ctx context.Context, habitat string) ([]string, error) {
+	switch habitat {
+	case "cave":
+		return []string{"zubat", "golbat"}, nil
+	case "rare":
+		return []string{"mewtwo"}, nil
+	default:
+		return nil, ErrHabitatNotFound
+	}
+}
+
+func TestPokemonRandomService(t *testing.T) {
+	testCases := []struct {
+		name          string
+		filter        RandomFilter
+		expectedNames []string
+		expectedError error
+	}{
+		{
+			name:          "Any Pokemon",
+			expectedNames: []string{"bulbasaur", "zubat", "golbat", "mewtwo"},
+		},
+		{
+			name:          "Legendary Only",
+			filter:        RandomFilter{Legendary: client.BoolPtr(true)},
+			expectedNames: []string{"mewtwo"},
+		},
+		{
+			name:          "Habitat",
+			filter:        RandomFilter{Habitat: "Cave"},
+			expectedNames: []string{"zubat", "golbat"},
+		},
+		{
+			name:          "No Legendary In Habitat",
+			filter:        RandomFilter{Habitat: "cave", Legendary: client.BoolPtr(true)},
+			expectedError: ErrNoPokemonMatch,
+		},
+		{
+			name:          "Unknown Habitat",
+			filter:        RandomFilter{Habitat: "moon"},
+			expectedError: ErrHabitatNotFound,
+		},
+		{
+			name:          "Invalid Habitat",
+			filter:        RandomFilter{Habitat: "m@@n"},
+			expectedError: ErrInvalidHabitat,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := PokemonRandomService(featuredGetter, featuredLister, featuredMembers, featuredLegendaries, 10)
+
+			for range 10 {
+				p, err := service(context.Background(), tc.filter, PokemonOptions{})
+
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+					continue
+				}
+				require.NoError(t, err)
+				assert.Contains(t, tc.expectedNames, p.Name)
+			}
+		})
+	}
+}
+
+func TestPokemonRandomServiceCandidates(t *testing.T) {
+	testCases := []struct {
+		name           string
+		legendary      bool
+		expectedName   string
+		expectedPicked []string
+	}{
+		{name: "Legendary Among The Legendary Species", legendary: true, expectedName: "mewtwo", expectedPicked: featuredLegendaries},
+		{name: "Not Legendary Without The Legendary Species", legendary: false, expectedPicked: []string{"bulbasaur", "zubat", "golbat", "missingno"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fetched []string
+			getter := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+				fetched = append(fetched, name)
+				return featuredGetter(ctx, name, opts)
+			}
+			service := PokemonRandomService(getter, featuredLister, featuredMembers, featuredLegendaries, 10)
+
+			for range 10 {
+				p, err := service(context.Background(), RandomFilter{Legendary: client.BoolPtr(tc.legendary)}, PokemonOptions{})
+				require.NoError(t, err)
+				if tc.expectedName != "" {
+					assert.Equal(t, tc.expectedName, p.Name)
+				}
+			}
+			assert.Subset(t, tc.expectedPicked, fetched)
+		})
+	}
+}
+
+func TestLegendarySpecies(t *testing.T) {
+	species := LegendarySpecies()
+	assert.Contains(t, species, "mewtwo")
+	assert.NotContains(t, species, "mew", "mythical species are not legendary")
+	assert.ElementsMatch(t, species, slices.Compact(slices.Sorted(slices.Values(species))), "no duplicates")
+}
+
+func TestPokemonDailyService(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	require.NoError(t, err)
+
+	service := PokemonDailyService(featuredGetter, featuredLister, rome, "seed", 10)
+	pick := func(date time.Time) string {
+		p, err := service(context.Background(), date, PokemonOptions{})
+		require.NoError(t, err)
+		return p.Name
+	}
+
+	t.Run("Same Day In The Configured Timezone", func(t *testing.T) {
+		morning := time.Date(2026, 10, 19, 8, 0, 0, 0, rome)
+		// 23:30 UTC of the 18th is already the 19th in Rome
+		assert.Equal(t, pick(morning), pick(time.Date(2026, 10, 18, 23, 30, 0, 0, time.UTC)))
+		assert.Equal(t, pick(morning), pick(time.Date(2026, 10, 19, 23, 59, 0, 0, rome)))
+	})
+
+	t.Run("Varies Across Days", func(t *testing.T) {
+		picks := map[string]bool{}
+		for day := range 30 {
+			picks[pick(time.Date(2026, 10, 1+day, 12, 0, 0, 0, rome))] = true
+		}
+		assert.Greater(t, len(picks), 1)
+		assert.NotContains(t, picks, "missingno")
+	})
+
+	t.Run("Depends On The Seed", func(t *testing.T) {
+		other := PokemonDailyService(featuredGetter, featuredLister, rome, "another seed", 10)
+		differ := false
+		for day := range 30 {
+			date := time.Date(2026, 10, 1+day, 12, 0, 0, 0, rome)
+			p, err := other(context.Background(), date, PokemonOptions{})
+			require.NoError(t, err)
+			differ = differ || p.Name != pick(date)
+		}
+		assert.True(t, differ)
+	})
+}
@@ -0,0 +1,11 @@
+package service
+
+import "slices"
+
+// FieldDescription is the field of the pokemon holding the description, the only one needing a translation.
+const FieldDescription = "description"
+
+// NeedsField reports whether field is part of the fields selected by the options.
+func (o PokemonOptions) NeedsField(field string) bool {
+	return o.Fields == nil || slices.Contains(o.Fields, field)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedsField(t *testing.T) {
+	assert.True(t, PokemonOptions{}.NeedsField(FieldDescription), "all fields are needed by default")
+
+	opts := PokemonOptions{Fields: []string{"name", "description"}}
+	assert.True(t, opts.NeedsField(FieldDescription))
+	assert.False(t, opts.NeedsField("habitat"))
+}
+
+func TestTranslationSkippedWithoutDescription(t *testing.T) {
+	getter := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		return model.Pokemon{Name: name, Description: "A mouse.", Habitat: "forest", IsLegendary: client.BoolPtr(false)}, nil
+	}
+	translations := 0
+	translator := func(ctx context.Context, style TranslationStyle, text string) (string, error) {
+		translations++
+		return "A mouse, forsooth.", nil
+	}
+
+	t.Run("Single Pokemon", func(t *testing.T) {
+		translations = 0
+		service := PokemonGetterTranslatorService(getter, translator)
+
+		p, err := service(context.Background(), "pikachu", PokemonOptions{Fields: []string{"name", "habitat"}})
+		require.NoError(t, err)
+		assert.Equal(t, "A mouse.", p.Description)
+		assert.Zero(t, translations)
+
+		p, err = service(context.Background(), "pikachu", PokemonOptions{Fields: []string{"description"}})
+		require.NoError(t, err)
+		assert.Equal(t, "A mouse, forsooth.", p.Description)
+		assert.Equal(t, 1, translations)
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		translations = 0
+		service := PokemonBatchTranslatorService(PokemonBatchService(getter, 1), translator, 1, 1)
+
+		results := service(context.Background(), []string{"pikachu", "raichu"}, PokemonOptions{Fields: []string{"name"}})
+		require.Len(t, results, 2)
+		assert.Zero(t, translations)
+		assert.Empty(t, results[1].Pokemon.Unavailable, "no budget is spent on skipped translations")
+	})
+}
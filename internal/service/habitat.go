@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+var (
+	ErrHabitatNotFound = errors.New("habitat not found")
+	ErrInvalidHabitat  = errors.New("invalid habitat name")
+)
+
+type HabitatMembersGetter func(ctx context.Context, habitat string) ([]string, error)
+
+// HabitatPokemonService lists the species living in a habitat page by page, capping the page size to maxPageSize.
+// Upstream returns every member at once, so pages are cut locally.
+func HabitatPokemonService(
+	getMembers HabitatMembersGetter,
+	maxPageSize int,
+) func(ctx context.Context, habitat string, limit, offset int) (model.NamePage, error) {
+	return func(ctx context.Context, habitat string, limit, offset int) (model.NamePage, error) {
+		habitat, err := NormalizeName(habitat)
+		if err != nil {
+			return model.NamePage{}, ErrInvalidHabitat
+		}
+
+		if limit < 1 || limit > maxPageSize {
+			limit = maxPageSize
+		}
+		if offset < 0 {
+			offset = 0
+		}
+
+		members, err := getMembers(ctx, habitat)
+		if err != nil {
+			return model.NamePage{}, err
+		}
+
+		start := min(offset, len(members))
+		end := min(start+limit, len(members))
+		return model.NamePage{
+			Names:  members[start:end],
+			Offset: offset,
+			Limit:  limit,
+			Total:  len(members),
+		}, nil
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHabitatPokemonService(t *testing.T) {
+	members := []string{"zubat", "golbat", "diglett", "dugtrio", "onix"}
+	mockGetter := func(ctx context.Context, habitat string) ([]string, error) {
+		if habitat != "cave" {
+			return nil, ErrHabitatNotFound
+		}
+		return members, nil
+	}
+
+	testCases := []struct {
+		name          string
+		habitat       string
+		limit         int
+		offset        int
+		expected      model.NamePage
+		expectedError error
+	}{
+		{
+			name:     "First Page",
+			habitat:  "cave",
+			limit:    2,
+			expected: model.NamePage{Names: []string{"zubat", "golbat"}, Limit: 2, Total: 5},
+		},
+		{
+			name:     "Last Page Is Cut Short",
+			habitat:  " Cave ",
+			limit:    2,
+			offset:   4,
+			expected: model.NamePage{Names: []string{"onix"}, Offset: 4, Limit: 2, Total: 5},
+		},
+		{
+			name:     "Offset Past The End",
+			habitat:  "cave",
+			limit:    2,
+			offset:   10,
+			expected: model.NamePage{Names: []string{}, Offset: 10, Limit: 2, Total: 5},
+		},
+		{
+			name:     "Default Page Size",
+			habitat:  "cave",
+			expected: model.NamePage{Names: []string{"zubat", "golbat", "diglett"}, Limit: 3, Total: 5},
+		},
+		{
+			name:          "Invalid Habitat",
+			habitat:       "cave/../forest",
+			expectedError: ErrInvalidHabitat,
+		},
+		{
+			name:          "Unknown Habitat",
+			habitat:       "moon",
+			expectedError: ErrHabitatNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := HabitatPokemonService(mockGetter, 3)
+			result, err := service(context.Background(), tc.habitat, tc.limit, tc.offset)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Include selects the optional sections of pokemon data to load on top of the species information.
+// Height and weight are loaded along with the stats.
+type Include struct {
+	Stats     bool
+	Types     bool
+	Abilities bool
+}
+
+const (
+	IncludeStats     = "stats"
+	IncludeTypes     = "types"
+	IncludeAbilities = "abilities"
+)
+
+var IncludeOptions = []string{IncludeStats, IncludeTypes, IncludeAbilities}
+
+// PokemonOptions are the options of a pokemon lookup, given by the client.
+type PokemonOptions struct {
+	// Include selects the optional sections of data to load.
+	Include Include
+	// Fields restricts the pokemon to the given fields, nil meaning all of them. Lookups may then skip
+	// the upstream calls that only serve the other fields.
+	Fields []string
+	// Style is the style the descriptions are translated in, instead of the one picked for each pokemon.
+	// Empty means Auto.
+	Style TranslationStyle
+}
+
+// ParseInclude parses include options, each of them possibly a comma separated list, e.g. "stats,types".
+func ParseInclude(options ...string) (Include, error) {
+	var inc Include
+	for _, opt := range strings.Split(strings.Join(options, ","), ",") {
+		switch strings.ToLower(strings.TrimSpace(opt)) {
+		case "":
+		case IncludeStats:
+			inc.Stats = true
+		case IncludeTypes:
+			inc.Types = true
+		case IncludeAbilities:
+			inc.Abilities = true
+		default:
+			return Include{}, fmt.Errorf("unknown include option %q, allowed options are: %s", opt, strings.Join(IncludeOptions, ", "))
+		}
+	}
+	return inc, nil
+}
+
+// Any reports whether at least one optional section is selected.
+func (i Include) Any() bool {
+	return i.Stats || i.Types || i.Abilities
+}
+
+// Names returns the selected options.
+func (i Include) Names() []string {
+	var names []string
+	if i.Stats {
+		names = append(names, IncludeStats)
+	}
+	if i.Types {
+		names = append(names, IncludeTypes)
+	}
+	if i.Abilities {
+		names = append(names, IncludeAbilities)
+	}
+	return names
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInclude(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       []string
+		expected    Include
+		expectError bool
+	}{
+		{name: "Empty", input: nil, expected: Include{}},
+		{name: "Single Option", input: []string{"stats"}, expected: Include{Stats: true}},
+		{name: "All Options", input: []string{"stats, Types,abilities"}, expected: Include{Stats: true, Types: true, Abilities: true}},
+		{name: "Trailing Comma", input: []string{"types,"}, expected: Include{Types: true}},
+		{name: "Separate Options", input: []string{"stats", "types"}, expected: Include{Stats: true, Types: true}},
+		{name: "Unknown Option", input: []string{"stats,moves"}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseInclude(tc.input...)
+
+			if tc.expectError {
+				assert.ErrorContains(t, err, "allowed options are: stats, types, abilities")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestIncludeNames(t *testing.T) {
+	assert.False(t, Include{}.Any())
+	assert.Empty(t, Include{}.Names())
+
+	inc := Include{Stats: true, Abilities: true}
+	assert.True(t, inc.Any())
+	assert.Equal(t, []string{IncludeStats, IncludeAbilities}, inc.Names())
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobFinished = errors.New("job already finished")
+	ErrQueueFull   = errors.New("too many queued jobs, retry later")
+	ErrJobsClosed  = errors.New("jobs are not accepted while shutting down")
+)
+
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// TranslationJob is a snapshot of a job translating the descriptions of Names.
+type TranslationJob struct {
+	ID     string
+	Status JobStatus
+	Style  TranslationStyle
+	Names  []string
+	// Results holds the outcome of the names done so far, in the order of Names.
+	Results []PokemonResult
+
+	CreatedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Finished reports whether the job is over, completed or canceled.
+func (j TranslationJob) Finished() bool {
+	return j.Status == JobCompleted || j.Status == JobCanceled
+}
+
+type job struct {
+	TranslationJob
+	opts   PokemonOptions
+	cancel context.CancelFunc
+}
+
+// TranslationJobs runs translation jobs in the background with a pool of workers, for the workloads too large
+// to be translated within a request. Each worker runs a job at a time, translating its names one by one.
+// At most maxQueued jobs wait for a worker, and finished jobs are forgotten after ttl.
+type TranslationJobs struct {
+	translate func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error)
+	onFinish  func(job TranslationJob)
+	ttl       time.Duration
+	now       func() time.Time
+
+	// ctx is canceled when the jobs left must stop, see Shutdown.
+	ctx    context.Context
+	cancel context.CancelFunc
+	queue  chan *job
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	jobs   map[string]*job
+	closed bool
+}
+
+// NewTranslationJobs starts workers running the jobs with translate, usually PokemonGetterTranslatorService:
+// the style of a job is set in the options it is given. onFinish, when set, is called with every job completed
+// or canceled and must not block.
+func NewTranslationJobs(
+	translate func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error),
+	onFinish func(job TranslationJob),
+	workers int,
+	maxQueued int,
+	ttl time.Duration,
+	now func() time.Time,
+) *TranslationJobs {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &TranslationJobs{
+		translate: translate,
+		onFinish:  onFinish,
+		ttl:       ttl,
+		now:       now,
+		ctx:       ctx,
+		cancel:    cancel,
+		queue:     make(chan *job, maxQueued),
+		jobs:      make(map[string]*job),
+	}
+
+	for range max(workers, 1) {
+		q.wg.Add(1)
+		go q.work()
+	}
+	return q
+}
+
+// Submit queues a job translating names in style, looked up with opts.
+func (q *TranslationJobs) Submit(_ context.Context, names []string, style TranslationStyle, opts PokemonOptions) (TranslationJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return TranslationJob{}, ErrJobsClosed
+	}
+	q.evict()
+
+	j := &job{
+		TranslationJob: TranslationJob{
+			ID:        rand.Text(),
+			Status:    JobQueued,
+			Style:     style,
+			Names:     slices.Clone(names),
+			CreatedAt: q.now(),
+		},
+		opts: opts,
+	}
+
+	select {
+	case q.queue <- j:
+	default:
+		return TranslationJob{}, ErrQueueFull
+	}
+	q.jobs[j.ID] = j
+
+	return j.snapshot(), nil
+}
+
+// Get returns the current state of the job id.
+func (q *TranslationJobs) Get(_ context.Context, id string) (TranslationJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.evict()
+	j, ok := q.jobs[id]
+	if !ok {
+		return TranslationJob{}, ErrJobNotFound
+	}
+	return j.snapshot(), nil
+}
+
+// Cancel stops the job id, keeping the results of the names already done.
+func (q *TranslationJobs) Cancel(_ context.Context, id string) (TranslationJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.evict()
+	j, ok := q.jobs[id]
+	if !ok {
+		return TranslationJob{}, ErrJobNotFound
+	}
+	if j.Finished() {
+		return j.snapshot(), ErrJobFinished
+	}
+
+	if j.cancel != nil {
+		j.cancel()
+	}
+	q.finish(j, JobCanceled)
+	return j.snapshot(), nil
+}
+
+// Shutdown stops accepting jobs and waits for the workers to run the jobs left. When ctx is done first,
+// the jobs still running or queued are canceled.
+func (q *TranslationJobs) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.queue)
+	}
+	q.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		<-drained
+		return ctx.Err()
+	}
+}
+
+func (q *TranslationJobs) work() {
+	defer q.wg.Done()
+
+	for j := range q.queue {
+		q.run(j)
+	}
+}
+
+func (q *TranslationJobs) run(j *job) {
+	q.mu.Lock()
+	if j.Finished() {
+		q.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(q.ctx)
+	defer cancel()
+	j.cancel = cancel
+	j.Status = JobRunning
+	opts := j.opts
+	opts.Style = j.Style
+	q.mu.Unlock()
+
+	for _, name := range j.Names {
+		if ctx.Err() != nil {
+			break
+		}
+		p, err := q.translate(ctx, name, opts)
+
+		q.mu.Lock()
+		if j.Finished() {
+			// canceled meanwhile
+			q.mu.Unlock()
+			return
+		}
+		j.Results = append(j.Results, PokemonResult{Name: name, Pokemon: p, Err: err})
+		q.mu.Unlock()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !j.Finished() {
+		status := JobCompleted
+		if len(j.Results) < len(j.Names) {
+			status = JobCanceled
+		}
+		q.finish(j, status)
+	}
+}
+
+func (q *TranslationJobs) finish(j *job, status JobStatus) {
+	j.Status = status
+	j.FinishedAt = q.now()
+	if q.onFinish != nil {
+		q.onFinish(j.snapshot())
+	}
+}
+
+// evict forgets the jobs finished for longer than the ttl. It must be called with mu held.
+func (q *TranslationJobs) evict() {
+	for id, j := range q.jobs {
+		if j.Finished() && q.now().Sub(j.FinishedAt) > q.ttl {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+func (j *job) snapshot() TranslationJob {
+	s := j.TranslationJob
+	s.Names = slices.Clone(j.Names)
+	s.Results = slices.Clone(j.Results)
+	return s
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingTranslate translates a name once released, or fails when ctx is done.
+type blockingTranslate struct {
+	started chan string
+	release chan struct{}
+}
+
+func newBlockingTranslate() *blockingTranslate {
+	return &blockingTranslate{started: make(chan string, 10), release: make(chan struct{})}
+}
+
+func (b *blockingTranslate) translate(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+	b.started <- name
+	select {
+	case <-b.release:
+		return model.Pokemon{Name: name}, nil
+	case <-ctx.Done():
+		return model.Pokemon{}, ctx.Err()
+	}
+}
+
+func TestTranslationJobs(t *testing.T) {
+	t.Run("jobs are run with their style and include", func(t *testing.T) {
+		translate := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+			if name == "missingno" {
+				return model.Pokemon{}, ErrNotFound
+			}
+			description := string(opts.Style)
+			if opts.Include.Types {
+				description += "+types"
+			}
+			return model.Pokemon{Name: name, Description: description}, nil
+		}
+		finished := make(chan TranslationJob, 1)
+		onFinish := func(job TranslationJob) { finished <- job }
+		jobs := NewTranslationJobs(translate, onFinish, 2, 10, time.Hour, time.Now)
+
+		submitted, err := jobs.Submit(context.Background(), []string{"pikachu", "missingno"}, Yoda, PokemonOptions{Include: Include{Types: true}})
+		require.NoError(t, err)
+		assert.Equal(t, JobQueued, submitted.Status)
+
+		// shutdown waits for the queued jobs
+		require.NoError(t, jobs.Shutdown(context.Background()))
+
+		job, err := jobs.Get(context.Background(), submitted.ID)
+		require.NoError(t, err)
+		assert.Equal(t, JobCompleted, job.Status)
+		assert.False(t, job.FinishedAt.IsZero())
+		require.Len(t, job.Results, 2)
+		assert.Equal(t, "yoda+types", job.Results[0].Pokemon.Description)
+		assert.ErrorIs(t, job.Results[1].Err, ErrNotFound)
+		assert.Equal(t, job, <-finished)
+
+		_, err = jobs.Submit(context.Background(), []string{"pikachu"}, Auto, PokemonOptions{})
+		assert.ErrorIs(t, err, ErrJobsClosed)
+	})
+
+	t.Run("queue length is bounded", func(t *testing.T) {
+		b := newBlockingTranslate()
+		jobs := NewTranslationJobs(b.translate, nil, 1, 1, time.Hour, time.Now)
+		defer jobs.Shutdown(context.Background())
+		defer close(b.release)
+
+		_, err := jobs.Submit(context.Background(), []string{"pikachu"}, Auto, PokemonOptions{})
+		require.NoError(t, err)
+		<-b.started
+
+		_, err = jobs.Submit(context.Background(), []string{"raichu"}, Auto, PokemonOptions{})
+		require.NoError(t, err)
+		_, err = jobs.Submit(context.Background(), []string{"pichu"}, Auto, PokemonOptions{})
+		assert.ErrorIs(t, err, ErrQueueFull)
+	})
+
+	t.Run("cancel keeps the results done", func(t *testing.T) {
+		b := newBlockingTranslate()
+		jobs := NewTranslationJobs(b.translate, nil, 1, 10, time.Hour, time.Now)
+		defer jobs.Shutdown(context.Background())
+
+		submitted, err := jobs.Submit(context.Background(), []string{"pikachu", "raichu"}, Auto, PokemonOptions{})
+		require.NoError(t, err)
+		<-b.started
+		b.release <- struct{}{}
+		<-b.started
+
+		job, err := jobs.Cancel(context.Background(), submitted.ID)
+		require.NoError(t, err)
+		assert.Equal(t, JobCanceled, job.Status)
+		assert.Equal(t, []PokemonResult{{Name: "pikachu", Pokemon: model.Pokemon{Name: "pikachu"}}}, job.Results)
+
+		_, err = jobs.Cancel(context.Background(), submitted.ID)
+		assert.ErrorIs(t, err, ErrJobFinished)
+		_, err = jobs.Cancel(context.Background(), "unknown")
+		assert.ErrorIs(t, err, ErrJobNotFound)
+	})
+
+	t.Run("queued jobs can be canceled", func(t *testing.T) {
+		b := newBlockingTranslate()
+		jobs := NewTranslationJobs(b.translate, nil, 1, 10, time.Hour, time.Now)
+
+		_, err := jobs.Submit(context.Background(), []string{"pikachu"}, Auto, PokemonOptions{})
+		require.NoError(t, err)
+		<-b.started
+		queued, err := jobs.Submit(context.Background(), []string{"raichu"}, Auto, PokemonOptions{})
+		require.NoError(t, err)
+
+		job, err := jobs.Cancel(context.Background(), queued.ID)
+		require.NoError(t, err)
+		assert.Equal(t, JobCanceled, job.Status)
+
+		close(b.release)
+		require.NoError(t, jobs.Shutdown(context.Background()))
+		// raichu was never translated
+		assert.Len(t, b.started, 0)
+	})
+
+	t.Run("shutdown timeout cancels the jobs left", func(t *testing.T) {
+		b := newBlockingTranslate()
+		jobs := NewTranslationJobs(b.translate, nil, 1, 10, time.Hour, time.Now)
+
+		running, err := jobs.Submit(context.Background(), []string{"pikachu", "raichu"}, Auto, PokemonOptions{})
+		require.NoError(t, err)
+		queued, err := jobs.Submit(context.Background(), []string{"pichu"}, Auto, PokemonOptions{})
+		require.NoError(t, err)
+		<-b.started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, jobs.Shutdown(ctx), context.DeadlineExceeded)
+
+		for _, id := range []string{running.ID, queued.ID} {
+			job, err := jobs.Get(context.Background(), id)
+			require.NoError(t, err)
+			assert.Equal(t, JobCanceled, job.Status)
+		}
+	})
+
+	t.Run("finished jobs expire", func(t *testing.T) {
+		var mu sync.Mutex
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		}
+		translate := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+			return model.Pokemon{Name: name}, nil
+		}
+		jobs := NewTranslationJobs(translate, nil, 1, 10, time.Hour, clock)
+
+		submitted, err := jobs.Submit(context.Background(), []string{"pikachu"}, Auto, PokemonOptions{})
+		require.NoError(t, err)
+		require.NoError(t, jobs.Shutdown(context.Background()))
+
+		mu.Lock()
+		now = now.Add(time.Hour)
+		mu.Unlock()
+		_, err = jobs.Get(context.Background(), submitted.ID)
+		require.NoError(t, err)
+
+		mu.Lock()
+		now = now.Add(time.Second)
+		mu.Unlock()
+		_, err = jobs.Get(context.Background(), submitted.ID)
+		assert.ErrorIs(t, err, ErrJobNotFound)
+	})
+}
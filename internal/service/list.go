@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+type PokemonLister func(ctx context.Context, limit, offset int) (model.NamePage, error)
+
+// PokemonListService lists pokemon names page by page, capping the page size to maxPageSize.
+func PokemonListService(lister PokemonLister, maxPageSize int) func(ctx context.Context, limit, offset int) (model.NamePage, error) {
+	return func(ctx context.Context, limit, offset int) (model.NamePage, error) {
+		if limit < 1 || limit > maxPageSize {
+			limit = maxPageSize
+		}
+		if offset < 0 {
+			offset = 0
+		}
+
+		return lister(ctx, limit, offset)
+	}
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPokemonListService(t *testing.T) {
+	testCases := []struct {
+		name           string
+		limit          int
+		offset         int
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{name: "Within Cap", limit: 10, offset: 20, expectedLimit: 10, expectedOffset: 20},
+		{name: "Above Cap", limit: 500, offset: 0, expectedLimit: 50, expectedOffset: 0},
+		{name: "Default Page Size", limit: 0, offset: 0, expectedLimit: 50, expectedOffset: 0},
+		{name: "Negative Offset", limit: 10, offset: -5, expectedLimit: 10, expectedOffset: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockLister := func(ctx context.Context, limit, offset int) (model.NamePage, error) {
+				assert.Equal(t, tc.expectedLimit, limit)
+				assert.Equal(t, tc.expectedOffset, offset)
+				return model.NamePage{Offset: offset, Limit: limit}, nil
+			}
+
+			service := PokemonListService(mockLister, 50)
+			_, err := service(context.Background(), tc.limit, tc.offset)
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidBatch = errors.New("invalid batch")
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// NormalizeName turns user input into the identifier used upstream: either a national pokedex ID
+// or a lowercase slug such as "mr-mime". Inner spaces, underscores and dots are accepted as separators,
+// and apostrophes are dropped, so that "Mr. Mime" and "Farfetch'd" are "mr-mime" and "farfetchd".
+func NormalizeName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer("'", "", "\u2019", "").Replace(name)
+	name = strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '.'
+	}), "-")
+
+	if id, err := strconv.Atoi(name); err == nil {
+		if id < 1 {
+			return "", ErrInvalidName
+		}
+		return strconv.Itoa(id), nil
+	}
+
+	if !slugPattern.MatchString(name) {
+		return "", ErrInvalidName
+	}
+
+	return name, nil
+}
+
+// CleanNames trims the names and drops the empty ones.
+func CleanNames(names []string) []string {
+	cleaned := make([]string, 0, len(names))
+	for _, n := range names {
+		if n = strings.TrimSpace(n); n != "" {
+			cleaned = append(cleaned, n)
+		}
+	}
+	return cleaned
+}
+
+// BatchNames cleans the names of a batch lookup and checks that there are between 1 and maxSize of them.
+// Both the HTTP and the gRPC APIs validate their batches with it.
+func BatchNames(names []string, maxSize int) ([]string, error) {
+	names = CleanNames(names)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: missing names", ErrInvalidBatch)
+	}
+	if len(names) > maxSize {
+		return nil, fmt.Errorf("%w: too many names: maximum batch size is %d", ErrInvalidBatch, maxSize)
+	}
+	return names, nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expected      string
+		expectedError error
+	}{
+		{name: "Lowercase Name", input: "mewtwo", expected: "mewtwo"},
+		{name: "Mixed Case Name", input: "Mewtwo", expected: "mewtwo"},
+		{name: "Surrounding Spaces", input: " mewtwo\t", expected: "mewtwo"},
+		{name: "Hyphenated Slug", input: "Mr-Mime", expected: "mr-mime"},
+		{name: "Inner Space As Separator", input: "mr mime", expected: "mr-mime"},
+		{name: "Dot And Space", input: "Mr. Mime", expected: "mr-mime"},
+		{name: "Trailing Dot", input: "Mime Jr.", expected: "mime-jr"},
+		{name: "Apostrophe", input: "Farfetch'd", expected: "farfetchd"},
+		{name: "Typographic Apostrophe", input: "Sirfetch\u2019d", expected: "sirfetchd"},
+		{name: "National Pokedex ID", input: "150", expected: "150"},
+		{name: "ID With Leading Zeros", input: "025", expected: "25"},
+		{name: "Zero ID", input: "0", expectedError: ErrInvalidName},
+		{name: "Negative ID", input: "-1", expectedError: ErrInvalidName},
+		{name: "Path Traversal", input: "../pokemon", expectedError: ErrInvalidName},
+		{name: "Query Characters", input: "mewtwo?limit=1", expectedError: ErrInvalidName},
+		{name: "Trailing Hyphen", input: "mewtwo-", expectedError: ErrInvalidName},
+		{name: "Empty", input: "  ", expectedError: ErrInvalidName},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := NormalizeName(tc.input)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestBatchNames(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         []string
+		expected      []string
+		expectedError string
+	}{
+		{name: "Clean Names", input: []string{"pikachu", "mewtwo"}, expected: []string{"pikachu", "mewtwo"}},
+		{name: "Blank Names Dropped", input: []string{" pikachu ", "", " "}, expected: []string{"pikachu"}},
+		{name: "Missing Names", input: []string{" ", ""}, expectedError: "invalid batch: missing names"},
+		{name: "Too Many Names", input: []string{"a", "b", "c"}, expectedError: "invalid batch: too many names: maximum batch size is 2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := BatchNames(tc.input, 2)
+
+			if tc.expectedError != "" {
+				assert.ErrorIs(t, err, ErrInvalidBatch)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/fprojetto/pokedex-api/internal/model"
 )
@@ -11,6 +13,8 @@ var (
 	ErrServiceUnavailable = errors.New("service unavailable")
 	ErrNotFound           = errors.New("pokemon not found")
 	ErrMissingData        = errors.New("pokemon data is missing")
+	ErrInvalidName        = errors.New("invalid pokemon name or id")
+	ErrInvalidStyle       = errors.New("invalid translation style")
 )
 
 type TranslationStyle string
@@ -18,14 +22,21 @@ type TranslationStyle string
 const (
 	Yoda        TranslationStyle = "yoda"
 	Shakespeare                  = "shakespeare"
+	// Auto picks Yoda for legendary and cave pokemon, Shakespeare for the others.
+	Auto TranslationStyle = "auto"
 )
 
-type PokemonInfoGetter func(ctx context.Context, name string) (model.Pokemon, error)
+type PokemonInfoGetter func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error)
 type Translator func(ctx context.Context, translationStyle TranslationStyle, text string) (string, error)
 
-func PokemonGetterService(getter PokemonInfoGetter) func(ctx context.Context, name string) (model.Pokemon, error) {
-	return func(ctx context.Context, name string) (model.Pokemon, error) {
-		p, err := getter(ctx, name)
+func PokemonGetterService(getter PokemonInfoGetter) func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+	return func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		name, err := NormalizeName(name)
+		if err != nil {
+			return model.Pokemon{}, err
+		}
+
+		p, err := getter(ctx, name, opts)
 		if err != nil {
 			return model.Pokemon{}, err
 		}
@@ -41,33 +52,97 @@ func PokemonGetterService(getter PokemonInfoGetter) func(ctx context.Context, na
 func PokemonGetterTranslatorService(
 	getter PokemonInfoGetter,
 	translator Translator,
-) func(ctx context.Context, name string) (model.Pokemon, error) {
-	getterService := PokemonGetterService(getter)
+) func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+	return PokemonTranslatedService(PokemonGetterService(getter), translator)
+}
+
+// PokemonTranslatedService translates the description of the pokemon returned by getter, whatever it is looked up by.
+func PokemonTranslatedService[A any](
+	getter func(ctx context.Context, arg A, opts PokemonOptions) (model.Pokemon, error),
+	translator Translator,
+) func(ctx context.Context, arg A, opts PokemonOptions) (model.Pokemon, error) {
 	translatorService := pokemonTranslatorService(translator)
-	return func(ctx context.Context, name string) (model.Pokemon, error) {
-		p, err := getterService(ctx, name)
+	return func(ctx context.Context, arg A, opts PokemonOptions) (model.Pokemon, error) {
+		p, err := getter(ctx, arg, opts)
 		if err != nil {
 			return model.Pokemon{}, err
 		}
 
-		return translatorService(ctx, p), nil
+		return translatorService(ctx, p, opts), nil
 	}
 }
 
-func pokemonTranslatorService(translator Translator) func(ctx context.Context, p model.Pokemon) model.Pokemon {
-	return func(ctx context.Context, p model.Pokemon) model.Pokemon {
-		var translationStyle TranslationStyle
-		if p.Habitat == "cave" || (p.IsLegendary != nil && *p.IsLegendary) {
-			translationStyle = Yoda
-		} else {
-			translationStyle = Shakespeare
+func pokemonTranslatorService(translator Translator) func(ctx context.Context, p model.Pokemon, opts PokemonOptions) model.Pokemon {
+	return func(ctx context.Context, p model.Pokemon, opts PokemonOptions) model.Pokemon {
+		if !opts.NeedsField(FieldDescription) {
+			return p
 		}
 
-		if translatedDescription, err := translator(ctx, translationStyle, p.Description); err == nil {
-			p.Description = translatedDescription
+		p, _ = translateDescription(ctx, translator, p, opts.Style)
+		return p
+	}
+}
+
+// translateDescription translates the description of p in style, the one picked for p when empty or Auto.
+// p is returned unchanged along with the error when the translation fails.
+func translateDescription(ctx context.Context, translator Translator, p model.Pokemon, style TranslationStyle) (model.Pokemon, error) {
+	if style == "" || style == Auto {
+		style = translationStyle(p)
+	}
+
+	translatedDescription, err := translator(ctx, style, p.Description)
+	if err != nil {
+		return p, err
+	}
+
+	p.Description = translatedDescription
+	return p, nil
+}
+
+// ParseTranslationStyle parses a translation style, empty meaning Auto.
+func ParseTranslationStyle(s string) (TranslationStyle, error) {
+	switch style := TranslationStyle(strings.ToLower(strings.TrimSpace(s))); style {
+	case "":
+		return Auto, nil
+	case Auto, Yoda, Shakespeare:
+		return style, nil
+	default:
+		return "", fmt.Errorf("%w %q, allowed styles are: %s, %s, %s", ErrInvalidStyle, s, Auto, Yoda, Shakespeare)
+	}
+}
+
+func translationStyle(p model.Pokemon) TranslationStyle {
+	if p.Habitat == "cave" || (p.IsLegendary != nil && *p.IsLegendary) {
+		return Yoda
+	}
+	return Shakespeare
+}
+
+// PokemonDescriptionService returns the description of p in lang, english when empty, translated with style if set.
+// Like for the translated endpoints the original description is returned when the translation fails.
+// ok is false when p has no description in lang.
+func PokemonDescriptionService(
+	translator Translator,
+) func(ctx context.Context, p model.Pokemon, lang string, style TranslationStyle) (description string, ok bool) {
+	return func(ctx context.Context, p model.Pokemon, lang string, style TranslationStyle) (string, bool) {
+		description := p.Description
+		if lang != "" && lang != "en" {
+			description = p.Descriptions[lang]
+		}
+		if description == "" {
+			return "", false
 		}
 
-		return p
+		if style == Auto {
+			style = translationStyle(p)
+		}
+		if style != "" {
+			if translated, err := translator(ctx, style, description); err == nil {
+				description = translated
+			}
+		}
+
+		return description, true
 	}
 }
 
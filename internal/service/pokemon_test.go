@@ -20,7 +20,7 @@ func TestPokemonGetterService(t *testing.T) {
 	}{
 		{
 			name: "Success - Valid Pokemon",
-			mockGetter: func(ctx context.Context, name string) (model.Pokemon, error) {
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
 				isLegendary := false
 				return model.Pokemon{
 					Name:        "charmander",
@@ -40,16 +40,49 @@ func TestPokemonGetterService(t *testing.T) {
 		},
 		{
 			name: "Error - Pokemon Not Found",
-			mockGetter: func(ctx context.Context, name string) (model.Pokemon, error) { // Assign the function directly
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) { // Assign the function directly
 				return model.Pokemon{}, ErrNotFound
 			},
 			pokemonName:   "nonexistent",
 			expected:      model.Pokemon{},
 			expectedError: ErrNotFound,
 		},
+		{
+			name: "Success - Name Normalized Before Lookup",
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+				assert.Equal(t, "charmander", name)
+				isLegendary := false
+				return model.Pokemon{
+					ID:          4,
+					Name:        "charmander",
+					Description: "A small orange lizard pokemon.",
+					Habitat:     "mountain",
+					IsLegendary: &isLegendary,
+				}, nil
+			},
+			pokemonName: " Charmander ",
+			expected: model.Pokemon{
+				ID:          4,
+				Name:        "charmander",
+				Description: "A small orange lizard pokemon.",
+				Habitat:     "mountain",
+				IsLegendary: client.BoolPtr(false),
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error - Invalid Name",
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+				t.Fatal("getter should not be called for an invalid name")
+				return model.Pokemon{}, nil
+			},
+			pokemonName:   "char/mander",
+			expected:      model.Pokemon{},
+			expectedError: ErrInvalidName,
+		},
 		{
 			name: "Error - Missing Data (empty name)",
-			mockGetter: func(ctx context.Context, name string) (model.Pokemon, error) { // Assign the function directly
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) { // Assign the function directly
 				isLegendary := false
 				return model.Pokemon{
 					Name:        "",
@@ -64,7 +97,7 @@ func TestPokemonGetterService(t *testing.T) {
 		},
 		{
 			name: "Error - Missing Data (nil isLegendary)",
-			mockGetter: func(ctx context.Context, name string) (model.Pokemon, error) { // Assign the function directly
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) { // Assign the function directly
 				return model.Pokemon{
 					Name:        "charmander",
 					Description: "A small orange lizard pokemon.",
@@ -82,7 +115,7 @@ func TestPokemonGetterService(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
 			service := PokemonGetterService(tc.mockGetter) // Use tc.mockGetter directly
-			result, err := service(ctx, tc.pokemonName)
+			result, err := service(ctx, tc.pokemonName, PokemonOptions{})
 
 			if tc.expectedError != nil {
 				assert.EqualError(t, err, tc.expectedError.Error())
@@ -109,7 +142,7 @@ func TestPokemonGetterTranslatorService(t *testing.T) {
 	}{
 		{
 			name: "Success - Yoda Translation for Legendary Pokemon",
-			mockGetter: func(ctx context.Context, name string) (model.Pokemon, error) { // Assign the function directly
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) { // Assign the function directly
 				isLegendary := true
 				return model.Pokemon{
 					Name:        "mewtwo",
@@ -133,7 +166,7 @@ func TestPokemonGetterTranslatorService(t *testing.T) {
 		},
 		{
 			name: "Success - Yoda Translation for Cave Habitat Pokemon",
-			mockGetter: func(ctx context.Context, name string) (model.Pokemon, error) { // Assign the function directly
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) { // Assign the function directly
 				isLegendary := false
 				return model.Pokemon{
 					Name:        "zubat",
@@ -157,7 +190,7 @@ func TestPokemonGetterTranslatorService(t *testing.T) {
 		},
 		{
 			name: "Success - Shakespeare Translation for Other Pokemon",
-			mockGetter: func(ctx context.Context, name string) (model.Pokemon, error) { // Assign the function directly
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) { // Assign the function directly
 				isLegendary := false
 				return model.Pokemon{
 					Name:        "pikachu",
@@ -181,7 +214,7 @@ func TestPokemonGetterTranslatorService(t *testing.T) {
 		},
 		{
 			name: "Success - Translation Fails, Original Description Returned",
-			mockGetter: func(ctx context.Context, name string) (model.Pokemon, error) { // Assign the function directly
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) { // Assign the function directly
 				isLegendary := false
 				return model.Pokemon{
 					Name:        "squirtle",
@@ -204,7 +237,7 @@ func TestPokemonGetterTranslatorService(t *testing.T) {
 		},
 		{
 			name: "Error - Pokemon Not Found by Getter",
-			mockGetter: func(ctx context.Context, name string) (model.Pokemon, error) { // Assign the function directly
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) { // Assign the function directly
 				return model.Pokemon{}, ErrNotFound
 			},
 			mockTranslator: func(ctx context.Context, style TranslationStyle, text string) (string, error) { // Assign the function directly
@@ -216,7 +249,7 @@ func TestPokemonGetterTranslatorService(t *testing.T) {
 		},
 		{
 			name: "Error - Missing Data from Getter",
-			mockGetter: func(ctx context.Context, name string) (model.Pokemon, error) { // Assign the function directly
+			mockGetter: func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) { // Assign the function directly
 				isLegendary := false
 				return model.Pokemon{
 					Name:        "", // Missing name
@@ -238,7 +271,7 @@ func TestPokemonGetterTranslatorService(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
 			service := PokemonGetterTranslatorService(tc.mockGetter, tc.mockTranslator) // Use tc.mockGetter and tc.mockTranslator directly
-			result, err := service(ctx, tc.pokemonName)
+			result, err := service(ctx, tc.pokemonName, PokemonOptions{})
 
 			if tc.expectedError != nil {
 				assert.EqualError(t, err, tc.expectedError.Error())
@@ -253,3 +286,116 @@ func TestPokemonGetterTranslatorService(t *testing.T) {
 		})
 	}
 }
+
+func TestPokemonDescriptionService(t *testing.T) {
+	mewtwo := model.Pokemon{
+		Name:         "mewtwo",
+		Description:  "A legendary psychic pokemon.",
+		Descriptions: map[string]string{"en": "A legendary psychic pokemon.", "fr": "Un pokemon psy legendaire."},
+		Habitat:      "rare",
+		IsLegendary:  client.BoolPtr(true),
+	}
+
+	testCases := []struct {
+		name           string
+		mockTranslator Translator
+		lang           string
+		style          TranslationStyle
+		expected       string
+		expectedOK     bool
+	}{
+		{
+			name:       "Success - English Description",
+			lang:       "en",
+			expected:   "A legendary psychic pokemon.",
+			expectedOK: true,
+		},
+		{
+			name:       "Success - Description in Another Language",
+			lang:       "fr",
+			expected:   "Un pokemon psy legendaire.",
+			expectedOK: true,
+		},
+		{
+			name: "Success - Automatic Style",
+			mockTranslator: func(ctx context.Context, style TranslationStyle, text string) (string, error) {
+				assert.Equal(t, Yoda, style)
+				return "Psychic pokemon, legendary it is.", nil
+			},
+			style:      Auto,
+			expected:   "Psychic pokemon, legendary it is.",
+			expectedOK: true,
+		},
+		{
+			name: "Success - Translation Fails, Original Description Returned",
+			mockTranslator: func(ctx context.Context, style TranslationStyle, text string) (string, error) {
+				return "", errors.New("translation service unavailable")
+			},
+			lang:       "fr",
+			style:      Shakespeare,
+			expected:   "Un pokemon psy legendaire.",
+			expectedOK: true,
+		},
+		{
+			name:       "Error - No Description in Language",
+			lang:       "ja",
+			style:      Yoda,
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			describe := PokemonDescriptionService(tc.mockTranslator)
+			description, ok := describe(context.Background(), mewtwo, tc.lang, tc.style)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expected, description)
+		})
+	}
+}
+
+func TestParseTranslationStyle(t *testing.T) {
+	tests := []struct {
+		input         string
+		expected      TranslationStyle
+		expectedError error
+	}{
+		{input: "", expected: Auto},
+		{input: "Yoda", expected: Yoda},
+		{input: " shakespeare ", expected: Shakespeare},
+		{input: "klingon", expectedError: ErrInvalidStyle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			style, err := ParseTranslationStyle(tt.input)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, style)
+		})
+	}
+}
+
+func TestPokemonGetterTranslatorServiceStyle(t *testing.T) {
+	getter := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		return model.Pokemon{
+			Name:        "mewtwo",
+			Description: "A legendary psychic pokemon.",
+			Habitat:     "rare",
+			IsLegendary: client.BoolPtr(true),
+		}, nil
+	}
+	translator := func(ctx context.Context, style TranslationStyle, text string) (string, error) {
+		return string(style), nil
+	}
+	service := PokemonGetterTranslatorService(getter, translator)
+
+	p, err := service(context.Background(), "mewtwo", PokemonOptions{Style: Shakespeare})
+	assert.NoError(t, err)
+	assert.Equal(t, "shakespeare", p.Description, "the style of the options wins over the one of the pokemon")
+
+	p, err = service(context.Background(), "mewtwo", PokemonOptions{Style: Auto})
+	assert.NoError(t, err)
+	assert.Equal(t, "yoda", p.Description)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+//go:embed data/species.txt
+var bundledSpecies string
+
+// NotFoundError is returned in place of ErrNotFound when similar names are known.
+type NotFoundError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *NotFoundError) Error() string {
+	return ErrNotFound.Error()
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return ErrNotFound
+}
+
+// Match is a name of the index matching a search query, the higher the score the better.
+type Match struct {
+	Name  string
+	Score float64
+}
+
+// NameIndex is an in-memory index of pokemon names supporting prefix and fuzzy matching.
+// It is safe for concurrent use and can be refreshed while serving searches.
+type NameIndex struct {
+	mu    sync.RWMutex
+	names []string
+}
+
+// NewNameIndex builds an index over names. When names is nil the bundled species list is used.
+func NewNameIndex(names []string) *NameIndex {
+	if names == nil {
+		names = strings.Fields(bundledSpecies)
+	}
+
+	idx := &NameIndex{}
+	idx.Replace(names)
+	return idx
+}
+
+// Replace swaps the indexed names.
+func (idx *NameIndex) Replace(names []string) {
+	normalized := make([]string, 0, len(names))
+	for _, n := range names {
+		if n = strings.ToLower(strings.TrimSpace(n)); n != "" {
+			normalized = append(normalized, n)
+		}
+	}
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+
+	idx.mu.Lock()
+	idx.names = normalized
+	idx.mu.Unlock()
+}
+
+// Len returns the number of indexed names.
+func (idx *NameIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.names)
+}
+
+// Search returns up to limit names matching query, best matches first.
+func (idx *NameIndex) Search(query string, limit int) []Match {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" || limit < 1 {
+		return nil
+	}
+
+	idx.mu.RLock()
+	var matches []Match
+	for _, name := range idx.names {
+		if score := matchScore(query, name); score > 0 {
+			matches = append(matches, Match{Name: name, Score: score})
+		}
+	}
+	idx.mu.RUnlock()
+
+	slices.SortFunc(matches, func(a, b Match) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Suggest returns up to limit names close to name, excluding name itself.
+func (idx *NameIndex) Suggest(name string, limit int) []string {
+	var suggestions []string
+	for _, m := range idx.Search(name, limit+1) {
+		if m.Score < 1 && len(suggestions) < limit {
+			suggestions = append(suggestions, m.Name)
+		}
+	}
+	return suggestions
+}
+
+// matchScore ranks exact matches first, then prefixes, substrings and finally names
+// within a small edit distance. Zero means no match.
+func matchScore(query, name string) float64 {
+	switch {
+	case query == name:
+		return 1
+	case strings.HasPrefix(name, query):
+		return 0.75 + 0.2*float64(len(query))/float64(len(name))
+	case strings.Contains(name, query):
+		return 0.5 + 0.2*float64(len(query))/float64(len(name))
+	}
+
+	maxDistance := max(1, len(query)/3)
+	distance := levenshtein(query, name)
+	if distance > maxDistance {
+		return 0
+	}
+
+	return 0.5 * (1 - float64(distance)/float64(max(len(query), len(name))))
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// PokemonSearchService searches the name index.
+func PokemonSearchService(idx *NameIndex) func(ctx context.Context, query string, limit int) []Match {
+	return func(_ context.Context, query string, limit int) []Match {
+		return idx.Search(query, limit)
+	}
+}
+
+// PokemonSuggestService decorates getter so that not found errors carry up to limit "did you mean" suggestions.
+func PokemonSuggestService(
+	getter func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error),
+	idx *NameIndex,
+	limit int,
+) func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+	return func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		p, err := getter(ctx, name, opts)
+		if errors.Is(err, ErrNotFound) {
+			if suggestions := idx.Suggest(name, limit); len(suggestions) > 0 {
+				return model.Pokemon{}, &NotFoundError{Name: name, Suggestions: suggestions}
+			}
+		}
+		return p, err
+	}
+}
+
+// RefreshNameIndex replaces the content of the index with every species known by lister.
+func RefreshNameIndex(ctx context.Context, idx *NameIndex, lister PokemonLister, pageSize int) error {
+	var names []string
+	for offset := 0; ; offset += pageSize {
+		page, err := lister(ctx, pageSize, offset)
+		if err != nil {
+			return fmt.Errorf("refreshing name index: %w", err)
+		}
+		names = append(names, page.Names...)
+
+		if len(page.Names) == 0 || offset+pageSize >= page.Total {
+			break
+		}
+	}
+
+	idx.Replace(names)
+	return nil
+}
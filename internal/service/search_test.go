@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameIndexSearch(t *testing.T) {
+	idx := NewNameIndex([]string{"pikachu", "raichu", "pichu", "mewtwo", "mew", "mr-mime", "Charmander"})
+
+	testCases := []struct {
+		name          string
+		query         string
+		limit         int
+		expectedNames []string
+	}{
+		{name: "Exact Match First", query: "mew", limit: 3, expectedNames: []string{"mew", "mewtwo"}},
+		{name: "Prefix Match", query: "pik", limit: 3, expectedNames: []string{"pikachu"}},
+		{name: "Typo Within Edit Distance", query: "pikachoo", limit: 3, expectedNames: []string{"pikachu"}},
+		{name: "Substring Match", query: "chu", limit: 5, expectedNames: []string{"pichu", "raichu", "pikachu"}},
+		{name: "Case Insensitive", query: " CHARMANDER ", limit: 1, expectedNames: []string{"charmander"}},
+		{name: "Limit Applied", query: "chu", limit: 1, expectedNames: []string{"pichu"}},
+		{name: "No Match", query: "zzzzzz", limit: 3, expectedNames: nil},
+		{name: "Empty Query", query: " ", limit: 3, expectedNames: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var names []string
+			for _, m := range idx.Search(tc.query, tc.limit) {
+				names = append(names, m.Name)
+			}
+
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestNewNameIndexBundled(t *testing.T) {
+	idx := NewNameIndex(nil)
+
+	assert.Equal(t, 151, idx.Len())
+	assert.Equal(t, []string{"pikachu"}, idx.Suggest("pikachoo", 3))
+}
+
+func TestPokemonSuggestService(t *testing.T) {
+	idx := NewNameIndex([]string{"pikachu", "raichu"})
+	getter := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		if name == "pikachu" {
+			return model.Pokemon{Name: name}, nil
+		}
+		return model.Pokemon{}, ErrNotFound
+	}
+	service := PokemonSuggestService(getter, idx, 3)
+
+	p, err := service(context.Background(), "pikachu", PokemonOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "pikachu", p.Name)
+
+	_, err = service(context.Background(), "pikachoo", PokemonOptions{})
+	var notFound *NotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, []string{"pikachu"}, notFound.Suggestions)
+
+	_, err = service(context.Background(), "zzzzzzzz", PokemonOptions{})
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestRefreshNameIndex(t *testing.T) {
+	all := []string{"bulbasaur", "ivysaur", "venusaur", "charmander", "charmeleon"}
+	lister := func(ctx context.Context, limit, offset int) (model.NamePage, error) {
+		end := min(offset+limit, len(all))
+		return model.NamePage{Names: all[offset:end], Offset: offset, Limit: limit, Total: len(all)}, nil
+	}
+	idx := NewNameIndex([]string{})
+
+	err := RefreshNameIndex(context.Background(), idx, lister, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, idx.Len())
+
+	failing := func(ctx context.Context, limit, offset int) (model.NamePage, error) {
+		return model.NamePage{}, ErrServiceUnavailable
+	}
+	err = RefreshNameIndex(context.Background(), idx, failing, 2)
+
+	assert.ErrorIs(t, err, ErrServiceUnavailable)
+	assert.Equal(t, 5, idx.Len())
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/pkg/cache"
+)
+
+var (
+	ErrSpriteNotFound       = errors.New("sprite not available")
+	ErrInvalidSpriteVariant = errors.New("invalid sprite variant")
+)
+
+const (
+	SpriteFrontDefault    = "front_default"
+	SpriteShiny           = "shiny"
+	SpriteOfficialArtwork = "official-artwork"
+)
+
+var SpriteVariants = []string{SpriteFrontDefault, SpriteShiny, SpriteOfficialArtwork}
+
+type SpriteURLResolver func(ctx context.Context, name string, variant string) (string, error)
+type SpriteFetcher func(ctx context.Context, spriteURL string) (model.Sprite, error)
+
+// SpriteStore keeps downloaded sprites, e.g. a *cache.DiskCache.
+type SpriteStore interface {
+	Get(key string) (cache.Blob, bool)
+	Set(key string, b cache.Blob) error
+}
+
+// PokemonSpriteService returns the sprite of a pokemon, from the store when possible.
+// The upstream is only called on a store miss, for both the URL resolution and the download.
+func PokemonSpriteService(
+	resolve SpriteURLResolver,
+	fetch SpriteFetcher,
+	store SpriteStore,
+) func(ctx context.Context, name string, variant string) (model.Sprite, error) {
+	return func(ctx context.Context, name string, variant string) (model.Sprite, error) {
+		name, err := NormalizeName(name)
+		if err != nil {
+			return model.Sprite{}, err
+		}
+		if variant == "" {
+			variant = SpriteFrontDefault
+		}
+		if !slices.Contains(SpriteVariants, variant) {
+			return model.Sprite{}, ErrInvalidSpriteVariant
+		}
+
+		key := name + "/" + variant
+		if b, ok := store.Get(key); ok {
+			return model.Sprite{ContentType: b.ContentType, ModTime: b.ModTime, Data: b.Data}, nil
+		}
+
+		spriteURL, err := resolve(ctx, name, variant)
+		if err != nil {
+			return model.Sprite{}, err
+		}
+
+		sprite, err := fetch(ctx, spriteURL)
+		if err != nil {
+			return model.Sprite{}, err
+		}
+
+		if err := store.Set(key, cache.Blob{ContentType: sprite.ContentType, ModTime: sprite.ModTime, Data: sprite.Data}); err != nil {
+			log.Printf("failed to cache sprite %s: %v", key, err)
+		}
+
+		return sprite, nil
+	}
+}
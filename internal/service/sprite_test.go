@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPokemonSpriteService(t *testing.T) {
+	resolveCalls, fetchCalls := 0, 0
+	resolve := func(ctx context.Context, name string, variant string) (string, error) {
+		resolveCalls++
+		if name == "missingno" {
+			return "", ErrNotFound
+		}
+		return "https://sprites.example/" + name + "/" + variant + ".png", nil
+	}
+	fetch := func(ctx context.Context, spriteURL string) (model.Sprite, error) {
+		fetchCalls++
+		return model.Sprite{ContentType: "image/png", ModTime: time.Unix(0, 0).UTC(), Data: []byte(spriteURL)}, nil
+	}
+	store, err := cache.NewDiskCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	service := PokemonSpriteService(resolve, fetch, store)
+
+	sprite, err := service(context.Background(), "Pikachu", "")
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", sprite.ContentType)
+	assert.Equal(t, []byte("https://sprites.example/pikachu/front_default.png"), sprite.Data)
+
+	cached, err := service(context.Background(), "pikachu", SpriteFrontDefault)
+	require.NoError(t, err)
+	assert.Equal(t, sprite.Data, cached.Data)
+	assert.True(t, sprite.ModTime.Equal(cached.ModTime))
+	assert.Equal(t, 1, resolveCalls)
+	assert.Equal(t, 1, fetchCalls)
+
+	_, err = service(context.Background(), "pikachu", SpriteShiny)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetchCalls)
+
+	_, err = service(context.Background(), "pikachu", "back_female")
+	assert.ErrorIs(t, err, ErrInvalidSpriteVariant)
+
+	_, err = service(context.Background(), "missingno", "")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestPokemonSpriteServiceEviction(t *testing.T) {
+	fetch := func(ctx context.Context, spriteURL string) (model.Sprite, error) {
+		return model.Sprite{ContentType: "image/png", Data: make([]byte, 400)}, nil
+	}
+	resolve := func(ctx context.Context, name string, variant string) (string, error) {
+		return name, nil
+	}
+	dir := t.TempDir()
+	store, err := cache.NewDiskCache(dir, 1000)
+	require.NoError(t, err)
+
+	service := PokemonSpriteService(resolve, fetch, store)
+	for _, name := range []string{"bulbasaur", "ivysaur", "venusaur"} {
+		_, err := service(context.Background(), name, "")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int64(800), store.Size())
+	_, ok := store.Get("bulbasaur/" + SpriteFrontDefault)
+	assert.False(t, ok, "least recently used sprite must be evicted")
+	_, ok = store.Get("venusaur/" + SpriteFrontDefault)
+	assert.True(t, ok)
+
+	reopened, err := cache.NewDiskCache(dir, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(800), reopened.Size(), "disk cache must survive restarts")
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+// TranslationEvent is the outcome of a single pokemon of a translation stream.
+type TranslationEvent struct {
+	// Index is the position of the pokemon in the names of the stream.
+	Index   int
+	Name    string
+	Pokemon model.Pokemon
+	// Err is set when the pokemon could not be looked up.
+	Err error
+	// TranslationErr is set when the description could not be translated, Pokemon keeping the original one.
+	TranslationErr error
+}
+
+// PokemonTranslationStreamService looks up and translates many pokemon, running at most concurrency of them
+// at a time, and sends each outcome as soon as it is known: events come in order of completion, not of names.
+// The channel is closed once every pokemon is done, or as soon as ctx is done.
+func PokemonTranslationStreamService(
+	getter func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error),
+	translator Translator,
+	concurrency int,
+) func(ctx context.Context, names []string, opts PokemonOptions) <-chan TranslationEvent {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return func(ctx context.Context, names []string, opts PokemonOptions) <-chan TranslationEvent {
+		events := make(chan TranslationEvent)
+		sem := make(chan struct{}, concurrency)
+
+		var wg sync.WaitGroup
+		for i, name := range names {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					return
+				}
+
+				event := TranslationEvent{Index: i, Name: name}
+				event.Pokemon, event.Err = getter(ctx, name, opts)
+				if event.Err == nil && opts.NeedsField(FieldDescription) {
+					event.Pokemon, event.TranslationErr = translateDescription(ctx, translator, event.Pokemon, opts.Style)
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(events)
+		}()
+
+		return events
+	}
+}
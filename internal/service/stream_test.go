@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPokemonTranslationStreamService(t *testing.T) {
+	getter := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		if name == "missingno" {
+			return model.Pokemon{}, ErrNotFound
+		}
+		return model.Pokemon{Name: name, Description: name + " description"}, nil
+	}
+	translator := func(ctx context.Context, style TranslationStyle, text string) (string, error) {
+		if text == "psyduck description" {
+			return "", ErrServiceUnavailable
+		}
+		return "translated " + text, nil
+	}
+	stream := PokemonTranslationStreamService(getter, translator, 2)
+
+	t.Run("every pokemon is sent once", func(t *testing.T) {
+		var events []TranslationEvent
+		for e := range stream(context.Background(), []string{"pikachu", "missingno", "psyduck"}, PokemonOptions{}) {
+			events = append(events, e)
+		}
+		sort.Slice(events, func(i, j int) bool { return events[i].Index < events[j].Index })
+
+		require.Len(t, events, 3)
+		assert.Equal(t, TranslationEvent{
+			Index:   0,
+			Name:    "pikachu",
+			Pokemon: model.Pokemon{Name: "pikachu", Description: "translated pikachu description"},
+		}, events[0])
+		assert.ErrorIs(t, events[1].Err, ErrNotFound)
+		assert.Equal(t, "psyduck description", events[2].Pokemon.Description)
+		assert.ErrorIs(t, events[2].TranslationErr, ErrServiceUnavailable)
+	})
+	t.Run("description not selected", func(t *testing.T) {
+		for e := range stream(context.Background(), []string{"psyduck"}, PokemonOptions{Fields: []string{"name"}}) {
+			assert.NoError(t, e.TranslationErr)
+			assert.Equal(t, "psyduck description", e.Pokemon.Description)
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		events := stream(ctx, []string{"pikachu", "psyduck", "bulbasaur"}, PokemonOptions{})
+		cancel()
+
+		// the channel is closed once canceled, whether the events are read or not
+		count := 0
+		for range events {
+			count++
+		}
+		assert.LessOrEqual(t, count, 3)
+	})
+}
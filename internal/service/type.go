@@ -0,0 +1,133 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+)
+
+var (
+	ErrTypeNotFound = errors.New("type not found")
+	ErrInvalidType  = errors.New("invalid type name")
+)
+
+type TypeGetter func(ctx context.Context, name string) (model.TypeRelations, error)
+
+func TypeService(getter TypeGetter) func(ctx context.Context, name string) (model.TypeRelations, error) {
+	return func(ctx context.Context, name string) (model.TypeRelations, error) {
+		name, err := NormalizeName(name)
+		if err != nil {
+			return model.TypeRelations{}, ErrInvalidType
+		}
+
+		return getter(ctx, name)
+	}
+}
+
+// AttackMultipliers returns the multipliers of the damage dealt by t to the types it is not neutral against.
+func AttackMultipliers(t model.TypeRelations) map[string]float64 {
+	return multipliers(t.DoubleDamageTo, t.HalfDamageTo, t.NoDamageTo)
+}
+
+// DefenseMultipliers returns the multipliers of the damage received by t from the types it is not neutral against.
+func DefenseMultipliers(t model.TypeRelations) map[string]float64 {
+	return multipliers(t.DoubleDamageFrom, t.HalfDamageFrom, t.NoDamageFrom)
+}
+
+func multipliers(double, half, none []string) map[string]float64 {
+	m := make(map[string]float64, len(double)+len(half)+len(none))
+	for _, t := range double {
+		m[t] = 2
+	}
+	for _, t := range half {
+		m[t] = 0.5
+	}
+	for _, t := range none {
+		m[t] = 0
+	}
+	return m
+}
+
+// DefenseMultiplier returns the multiplier of a move of attackType against a pokemon of the defender types.
+// Dual types multiply their multipliers, so a double weakness is x4 and any immunity is x0.
+func DefenseMultiplier(attackType string, defender []model.TypeRelations) float64 {
+	multiplier := 1.0
+	for _, t := range defender {
+		if m, ok := DefenseMultipliers(t)[attackType]; ok {
+			multiplier *= m
+		}
+	}
+	return multiplier
+}
+
+// pokemonTypes returns the damage relations of every type of the pokemon returned by getPokemon.
+func pokemonTypes(
+	ctx context.Context,
+	getPokemon func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error),
+	getType TypeGetter,
+	name string,
+) (model.Pokemon, []model.TypeRelations, error) {
+	p, err := getPokemon(ctx, name, PokemonOptions{Include: Include{Types: true}})
+	if err != nil {
+		return model.Pokemon{}, nil, err
+	}
+	if len(p.Unavailable) > 0 {
+		return model.Pokemon{}, nil, ErrServiceUnavailable
+	}
+	if len(p.Types) == 0 {
+		return model.Pokemon{}, nil, ErrMissingData
+	}
+
+	relations, err := typeRelations(ctx, getType, p.Types)
+	if err != nil {
+		return model.Pokemon{}, nil, err
+	}
+
+	return p, relations, nil
+}
+
+// PokemonWeaknessService computes the weaknesses, resistances and immunities of a pokemon from its types.
+func PokemonWeaknessService(
+	getPokemon func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error),
+	getType TypeGetter,
+) func(ctx context.Context, name string) (model.DefenseMatchup, error) {
+	return func(ctx context.Context, name string) (model.DefenseMatchup, error) {
+		p, relations, err := pokemonTypes(ctx, getPokemon, getType, name)
+		if err != nil {
+			return model.DefenseMatchup{}, err
+		}
+
+		attackTypes := map[string]struct{}{}
+		for _, r := range relations {
+			for t := range DefenseMultipliers(r) {
+				attackTypes[t] = struct{}{}
+			}
+		}
+
+		matchup := model.DefenseMatchup{Name: p.Name, Types: p.Types}
+		for t := range attackTypes {
+			switch m := DefenseMultiplier(t, relations); {
+			case m == 0:
+				matchup.Immunities = append(matchup.Immunities, t)
+			case m > 1:
+				matchup.Weaknesses = append(matchup.Weaknesses, model.TypeMultiplier{Type: t, Multiplier: m})
+			case m < 1:
+				matchup.Resistances = append(matchup.Resistances, model.TypeMultiplier{Type: t, Multiplier: m})
+			}
+		}
+
+		slices.SortFunc(matchup.Weaknesses, func(a, b model.TypeMultiplier) int {
+			return cmp.Or(cmp.Compare(b.Multiplier, a.Multiplier), strings.Compare(a.Type, b.Type))
+		})
+		slices.SortFunc(matchup.Resistances, func(a, b model.TypeMultiplier) int {
+			return cmp.Or(cmp.Compare(a.Multiplier, b.Multiplier), strings.Compare(a.Type, b.Type))
+		})
+		slices.Sort(matchup.Immunities)
+
+		return matchup, nil
+	}
+}
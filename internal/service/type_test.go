@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/internal/model"
+	"github.com/fprojetto/pokedex-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fireType = model.TypeRelations{
+		Name:             "fire",
+		DoubleDamageTo:   []string{"grass", "ice", "bug", "steel"},
+		HalfDamageTo:     []string{"fire", "water", "rock", "dragon"},
+		DoubleDamageFrom: []string{"water", "ground", "rock"},
+		HalfDamageFrom:   []string{"fire", "grass", "ice", "bug", "steel", "fairy"},
+	}
+	flyingType = model.TypeRelations{
+		Name:             "flying",
+		DoubleDamageTo:   []string{"grass", "fighting", "bug"},
+		HalfDamageTo:     []string{"electric", "rock", "steel"},
+		DoubleDamageFrom: []string{"electric", "ice", "rock"},
+		HalfDamageFrom:   []string{"grass", "fighting", "bug"},
+		NoDamageFrom:     []string{"ground"},
+	}
+)
+
+func mockTypeGetter(ctx context.Context, name string) (model.TypeRelations, error) {
+	switch name {
+	case "fire":
+		return fireType, nil
+	case "flying":
+		return flyingType, nil
+	}
+	return model.TypeRelations{}, ErrTypeNotFound
+}
+
+func TestDefenseMultiplier(t *testing.T) {
+	charizard := []model.TypeRelations{fireType, flyingType}
+
+	testCases := []struct {
+		attackType string
+		expected   float64
+	}{
+		{attackType: "rock", expected: 4},
+		{attackType: "water", expected: 2},
+		{attackType: "ground", expected: 0},
+		{attackType: "grass", expected: 0.25},
+		{attackType: "fighting", expected: 0.5},
+		{attackType: "normal", expected: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.attackType, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DefenseMultiplier(tc.attackType, charizard))
+		})
+	}
+}
+
+func TestAttackMultipliers(t *testing.T) {
+	m := AttackMultipliers(flyingType)
+
+	assert.Equal(t, 2.0, m["grass"])
+	assert.Equal(t, 0.5, m["steel"])
+	_, ok := m["normal"]
+	assert.False(t, ok, "neutral types are not listed")
+}
+
+func TestPokemonWeaknessService(t *testing.T) {
+	getPokemon := func(ctx context.Context, name string, opts PokemonOptions) (model.Pokemon, error) {
+		if !opts.Include.Types {
+			t.Fatal("types must be requested")
+		}
+		switch name {
+		case "charizard":
+			return model.Pokemon{Name: "charizard", IsLegendary: client.BoolPtr(false), Types: []string{"fire", "flying"}}, nil
+		case "partial":
+			return model.Pokemon{Name: "partial", Unavailable: []string{IncludeTypes}}, nil
+		}
+		return model.Pokemon{}, ErrNotFound
+	}
+	service := PokemonWeaknessService(getPokemon, mockTypeGetter)
+
+	matchup, err := service(context.Background(), "charizard")
+	require.NoError(t, err)
+	assert.Equal(t, model.DefenseMatchup{
+		Name:  "charizard",
+		Types: []string{"fire", "flying"},
+		Weaknesses: []model.TypeMultiplier{
+			{Type: "rock", Multiplier: 4},
+			{Type: "electric", Multiplier: 2},
+			{Type: "water", Multiplier: 2},
+		},
+		Resistances: []model.TypeMultiplier{
+			{Type: "bug", Multiplier: 0.25},
+			{Type: "grass", Multiplier: 0.25},
+			{Type: "fairy", Multiplier: 0.5},
+			{Type: "fighting", Multiplier: 0.5},
+			{Type: "fire", Multiplier: 0.5},
+			{Type: "steel", Multiplier: 0.5},
+		},
+		Immunities: []string{"ground"},
+	}, matchup)
+
+	_, err = service(context.Background(), "partial")
+	assert.ErrorIs(t, err, ErrServiceUnavailable)
+
+	_, err = service(context.Background(), "missingno")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTypeService(t *testing.T) {
+	service := TypeService(mockTypeGetter)
+
+	relations, err := service(context.Background(), " Fire ")
+	require.NoError(t, err)
+	assert.Equal(t, "fire", relations.Name)
+
+	_, err = service(context.Background(), "fire/../")
+	assert.ErrorIs(t, err, ErrInvalidType)
+
+	_, err = service(context.Background(), "shadow")
+	assert.ErrorIs(t, err, ErrTypeNotFound)
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook")
+	ErrTooManyWebhooks = errors.New("too many webhooks")
+)
+
+// Event types of the webhooks.
+const (
+	EventJobCompleted    = "job.completed"
+	EventJobCanceled     = "job.canceled"
+	EventBatchTranslated = "batch.translated"
+)
+
+var WebhookEventTypes = []string{EventJobCompleted, EventJobCanceled, EventBatchTranslated}
+
+// WebhookSubscription receives the events of Events at URL, signed with Secret.
+type WebhookSubscription struct {
+	ID        string
+	URL       string
+	Events    []string
+	Secret    string
+	CreatedAt time.Time
+}
+
+type WebhookEvent struct {
+	ID        string
+	Type      string
+	CreatedAt time.Time
+	// Data is the payload of the event, sent as JSON.
+	Data any
+}
+
+// WebhookDelivery is the sending of an event to a subscription.
+type WebhookDelivery struct {
+	ID           string
+	Subscription WebhookSubscription
+	Event        WebhookEvent
+	// Attempt counts the attempts to deliver, starting from 1.
+	Attempt int
+}
+
+// DeadLetter is a delivery given up after its last attempt failed.
+type DeadLetter struct {
+	Delivery WebhookDelivery
+	Err      string
+	FailedAt time.Time
+}
+
+type WebhookSender func(ctx context.Context, d WebhookDelivery) error
+
+// WebhookResolver resolves the host of the url of a webhook to its ip addresses.
+type WebhookResolver func(ctx context.Context, host string) ([]netip.Addr, error)
+
+// IsPublicAddr reports whether webhooks may be delivered to addr: it is not a loopback, private, link-local,
+// unspecified or multicast address, so that webhooks cannot reach the network of the server, e.g. the
+// metadata endpoint of a cloud provider at 169.254.169.254.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !addr.IsLinkLocalUnicast()
+}
+
+// WebhookRetryPolicy tells how failed deliveries are retried: the delay before an attempt doubles from
+// Backoff up to MaxBackoff, until MaxAttempts attempts have failed.
+type WebhookRetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+func (p WebhookRetryPolicy) delay(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxBackoff)
+}
+
+// WebhookLimits bounds the resources of the webhooks: at most MaxSubscriptions subscriptions, whose deliveries
+// are made by Workers workers, MaxQueued of them waiting for a worker at most. The deliveries beyond are given up.
+// The last MaxDeadLetters deliveries given up are kept for inspection.
+type WebhookLimits struct {
+	MaxSubscriptions int
+	Workers          int
+	MaxQueued        int
+	MaxDeadLetters   int
+}
+
+// Webhooks keeps the webhook subscriptions and delivers the events published to them in the background,
+// within limits. The urls whose host resolve, with
+// resolve, to an address that is not public are rejected, see IsPublicAddr; every url is accepted when
+// resolve is nil.
+type Webhooks struct {
+	send    WebhookSender
+	resolve WebhookResolver
+	policy  WebhookRetryPolicy
+	limits  WebhookLimits
+	now     func() time.Time
+
+	// ctx is canceled when the deliveries left must stop, see Shutdown.
+	ctx    context.Context
+	cancel context.CancelFunc
+	queue  chan WebhookDelivery
+	wg     sync.WaitGroup
+
+	mu            sync.Mutex
+	subscriptions map[string]WebhookSubscription
+	deadLetters   []DeadLetter
+	closed        bool
+}
+
+func NewWebhooks(
+	send WebhookSender,
+	resolve WebhookResolver,
+	policy WebhookRetryPolicy,
+	limits WebhookLimits,
+	now func() time.Time,
+) *Webhooks {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Webhooks{
+		send:          send,
+		resolve:       resolve,
+		policy:        policy,
+		limits:        limits,
+		now:           now,
+		ctx:           ctx,
+		cancel:        cancel,
+		queue:         make(chan WebhookDelivery, limits.MaxQueued),
+		subscriptions: make(map[string]WebhookSubscription),
+	}
+
+	for range max(limits.Workers, 1) {
+		w.wg.Add(1)
+		go w.work()
+	}
+	return w
+}
+
+// Subscribe registers a subscription to events. A secret is generated when none is given.
+func (w *Webhooks) Subscribe(ctx context.Context, rawURL string, events []string, secret string) (WebhookSubscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return WebhookSubscription{}, fmt.Errorf("%w: url must be an absolute http or https url", ErrInvalidWebhook)
+	}
+	if err := w.checkHost(ctx, u.Hostname()); err != nil {
+		return WebhookSubscription{}, err
+	}
+	if len(events) == 0 {
+		return WebhookSubscription{}, fmt.Errorf("%w: missing events", ErrInvalidWebhook)
+	}
+	for _, e := range events {
+		if !slices.Contains(WebhookEventTypes, e) {
+			return WebhookSubscription{}, fmt.Errorf("%w: unknown event %q, allowed events are: %s",
+				ErrInvalidWebhook, e, strings.Join(WebhookEventTypes, ", "))
+		}
+	}
+	if secret == "" {
+		secret = rand.Text()
+	}
+
+	sub := WebhookSubscription{
+		ID:        rand.Text(),
+		URL:       rawURL,
+		Events:    slices.Compact(slices.Sorted(slices.Values(events))),
+		Secret:    secret,
+		CreatedAt: w.now(),
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.subscriptions) >= w.limits.MaxSubscriptions {
+		return WebhookSubscription{}, fmt.Errorf("%w: at most %d webhooks can be registered", ErrTooManyWebhooks, w.limits.MaxSubscriptions)
+	}
+	w.subscriptions[sub.ID] = sub
+
+	return sub, nil
+}
+
+// checkHost rejects the hosts resolving to an address that is not public. The addresses are checked again
+// when delivering, as they may change meanwhile.
+func (w *Webhooks) checkHost(ctx context.Context, host string) error {
+	if w.resolve == nil {
+		return nil
+	}
+
+	addrs, err := w.resolve(ctx, host)
+	if err != nil {
+		return fmt.Errorf("%w: cannot resolve host %s", ErrInvalidWebhook, host)
+	}
+	for _, addr := range addrs {
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("%w: url must not resolve to a loopback, private or link-local address", ErrInvalidWebhook)
+		}
+	}
+	return nil
+}
+
+// Unsubscribe removes the subscription id. Deliveries already started are not stopped.
+func (w *Webhooks) Unsubscribe(_ context.Context, id string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.subscriptions[id]; !ok {
+		return ErrWebhookNotFound
+	}
+	delete(w.subscriptions, id)
+	return nil
+}
+
+// Subscriptions returns the subscriptions, oldest first.
+func (w *Webhooks) Subscriptions(_ context.Context) []WebhookSubscription {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	subs := make([]WebhookSubscription, 0, len(w.subscriptions))
+	for _, s := range w.subscriptions {
+		subs = append(subs, s)
+	}
+	slices.SortFunc(subs, func(a, b WebhookSubscription) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return subs
+}
+
+// DeadLetters returns the deliveries given up, oldest first.
+func (w *Webhooks) DeadLetters(_ context.Context) []DeadLetter {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.deadLetters)
+}
+
+// Publish queues the deliveries of an event of type eventType to the subscriptions to it, without waiting for them.
+// The deliveries finding the queue full are given up.
+func (w *Webhooks) Publish(eventType string, data any) {
+	event := WebhookEvent{ID: rand.Text(), Type: eventType, CreatedAt: w.now(), Data: data}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		log.Printf("webhook event %s dropped while shutting down", eventType)
+		return
+	}
+	for _, sub := range w.subscriptions {
+		if !slices.Contains(sub.Events, eventType) {
+			continue
+		}
+
+		d := WebhookDelivery{ID: rand.Text(), Subscription: sub, Event: event}
+		select {
+		case w.queue <- d:
+		default:
+			log.Printf("webhook delivery %s of %s to %s given up: the queue is full", d.ID, eventType, sub.URL)
+			w.addDeadLetter(d, errors.New("the delivery queue is full"))
+		}
+	}
+}
+
+// Shutdown stops accepting events and waits for the deliveries left. When ctx is done first,
+// they are given up and kept as dead letters.
+func (w *Webhooks) Shutdown(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		w.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (w *Webhooks) work() {
+	defer w.wg.Done()
+
+	for d := range w.queue {
+		w.deliver(d)
+	}
+}
+
+// deliver makes the attempts of d, keeping its worker busy until it succeeds or is given up.
+func (w *Webhooks) deliver(d WebhookDelivery) {
+	if w.ctx.Err() != nil {
+		w.giveUp(d, errors.New("canceled at shutdown"))
+		return
+	}
+
+	var err error
+	for d.Attempt = 1; d.Attempt <= max(w.policy.MaxAttempts, 1); d.Attempt++ {
+		if d.Attempt > 1 {
+			timer := time.NewTimer(w.policy.delay(d.Attempt - 1))
+			select {
+			case <-timer.C:
+			case <-w.ctx.Done():
+				timer.Stop()
+				d.Attempt--
+				w.giveUp(d, fmt.Errorf("canceled at shutdown after: %w", err))
+				return
+			}
+		}
+
+		if err = w.send(w.ctx, d); err == nil {
+			return
+		}
+		log.Printf("webhook delivery %s of %s to %s failed (attempt %d): %v",
+			d.ID, d.Event.Type, d.Subscription.URL, d.Attempt, err)
+	}
+
+	d.Attempt--
+	w.giveUp(d, err)
+}
+
+func (w *Webhooks) giveUp(d WebhookDelivery, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.addDeadLetter(d, err)
+}
+
+// addDeadLetter keeps d given up with err, w.mu being held.
+func (w *Webhooks) addDeadLetter(d WebhookDelivery, err error) {
+	w.deadLetters = append(w.deadLetters, DeadLetter{Delivery: d, Err: err.Error(), FailedAt: w.now()})
+	if over := len(w.deadLetters) - w.limits.MaxDeadLetters; over > 0 {
+		w.deadLetters = slices.Delete(w.deadLetters, 0, over)
+	}
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSender records the deliveries, failing the first failures attempts of each.
+type fakeSender struct {
+	failures int
+
+	mu         sync.Mutex
+	deliveries []WebhookDelivery
+}
+
+func (f *fakeSender) send(_ context.Context, d WebhookDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.deliveries = append(f.deliveries, d)
+	if d.Attempt <= f.failures {
+		return errors.New("unexpected response status 500")
+	}
+	return nil
+}
+
+// fakeResolver resolves the hosts of its map.
+type fakeResolver map[string][]netip.Addr
+
+func (f fakeResolver) resolve(_ context.Context, host string) ([]netip.Addr, error) {
+	addrs, ok := f[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+var resolver = fakeResolver{
+	"example.com":        {netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("2606:2800:21f:cb07:6820:80da:af6b:8b2c")},
+	"localhost":          {netip.MustParseAddr("127.0.0.1"), netip.MustParseAddr("::1")},
+	"internal.example":   {netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("10.0.0.1")},
+	"169.254.169.254":    {netip.MustParseAddr("169.254.169.254")},
+	"::ffff:192.168.1.1": {netip.MustParseAddr("::ffff:192.168.1.1")},
+}
+
+var fastRetries = WebhookRetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+var limits = WebhookLimits{MaxSubscriptions: 10, Workers: 2, MaxQueued: 10, MaxDeadLetters: 10}
+
+// blockingSender blocks the deliveries until released, recording how many are made at the same time.
+type blockingSender struct {
+	started chan struct{}
+	release chan struct{}
+
+	mu      sync.Mutex
+	running int
+	maxSeen int
+}
+
+func (b *blockingSender) send(_ context.Context, _ WebhookDelivery) error {
+	b.mu.Lock()
+	b.running++
+	b.maxSeen = max(b.maxSeen, b.running)
+	b.mu.Unlock()
+
+	b.started <- struct{}{}
+	<-b.release
+
+	b.mu.Lock()
+	b.running--
+	b.mu.Unlock()
+	return nil
+}
+
+func TestWebhooksSubscribe(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		events  []string
+		wantErr string
+	}{
+		{name: "valid", url: "https://example.com/hook", events: []string{EventJobCanceled, EventJobCompleted, EventJobCompleted}},
+		{name: "relative url", url: "/hook", events: []string{EventJobCompleted}, wantErr: "invalid webhook: url must be an absolute http or https url"},
+		{name: "unsupported scheme", url: "ftp://example.com", events: []string{EventJobCompleted}, wantErr: "invalid webhook: url must be an absolute http or https url"},
+		{name: "loopback address", url: "http://localhost:8080/hook", events: []string{EventJobCompleted}, wantErr: "invalid webhook: url must not resolve to a loopback, private or link-local address"},
+		{name: "private address", url: "https://internal.example/hook", events: []string{EventJobCompleted}, wantErr: "invalid webhook: url must not resolve to a loopback, private or link-local address"},
+		{name: "link-local address", url: "http://169.254.169.254/latest/meta-data", events: []string{EventJobCompleted}, wantErr: "invalid webhook: url must not resolve to a loopback, private or link-local address"},
+		{name: "ipv4-mapped private address", url: "http://[::ffff:192.168.1.1]/hook", events: []string{EventJobCompleted}, wantErr: "invalid webhook: url must not resolve to a loopback, private or link-local address"},
+		{name: "unknown host", url: "https://unknown.example/hook", events: []string{EventJobCompleted}, wantErr: "invalid webhook: cannot resolve host unknown.example"},
+		{name: "missing events", url: "https://example.com/hook", wantErr: "invalid webhook: missing events"},
+		{name: "unknown event", url: "https://example.com/hook", events: []string{"job.started"}, wantErr: `invalid webhook: unknown event "job.started", allowed events are: job.completed, job.canceled, batch.translated`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhooks := NewWebhooks((&fakeSender{}).send, resolver.resolve, fastRetries, limits, time.Now)
+
+			sub, err := webhooks.Subscribe(context.Background(), tt.url, tt.events, "")
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidWebhook)
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, sub.ID)
+			assert.NotEmpty(t, sub.Secret)
+			assert.Equal(t, []string{EventJobCanceled, EventJobCompleted}, sub.Events)
+			assert.Equal(t, []WebhookSubscription{sub}, webhooks.Subscriptions(context.Background()))
+		})
+	}
+}
+
+func TestWebhooksSubscribeLimit(t *testing.T) {
+	webhooks := NewWebhooks((&fakeSender{}).send, nil, fastRetries, WebhookLimits{MaxSubscriptions: 1, Workers: 1}, time.Now)
+	_, err := webhooks.Subscribe(context.Background(), "https://example.com/hook", []string{EventJobCompleted}, "")
+	require.NoError(t, err)
+
+	_, err = webhooks.Subscribe(context.Background(), "https://example.com/other", []string{EventJobCompleted}, "")
+	assert.ErrorIs(t, err, ErrTooManyWebhooks)
+	assert.EqualError(t, err, "too many webhooks: at most 1 webhooks can be registered")
+	assert.Len(t, webhooks.Subscriptions(context.Background()), 1)
+}
+
+func TestWebhooksUnsubscribe(t *testing.T) {
+	webhooks := NewWebhooks((&fakeSender{}).send, nil, fastRetries, limits, time.Now)
+	sub, err := webhooks.Subscribe(context.Background(), "https://example.com/hook", []string{EventJobCompleted}, "secret")
+	require.NoError(t, err)
+
+	require.NoError(t, webhooks.Unsubscribe(context.Background(), sub.ID))
+	assert.Empty(t, webhooks.Subscriptions(context.Background()))
+	assert.ErrorIs(t, webhooks.Unsubscribe(context.Background(), sub.ID), ErrWebhookNotFound)
+}
+
+func TestWebhooksPublish(t *testing.T) {
+	t.Run("events are delivered to their subscriptions", func(t *testing.T) {
+		sender := &fakeSender{}
+		webhooks := NewWebhooks(sender.send, nil, fastRetries, limits, time.Now)
+		completed, err := webhooks.Subscribe(context.Background(), "https://example.com/completed", []string{EventJobCompleted}, "secret")
+		require.NoError(t, err)
+		_, err = webhooks.Subscribe(context.Background(), "https://example.com/canceled", []string{EventJobCanceled}, "secret")
+		require.NoError(t, err)
+
+		webhooks.Publish(EventJobCompleted, "job1")
+		require.NoError(t, webhooks.Shutdown(context.Background()))
+
+		require.Len(t, sender.deliveries, 1)
+		assert.Equal(t, completed, sender.deliveries[0].Subscription)
+		assert.Equal(t, EventJobCompleted, sender.deliveries[0].Event.Type)
+		assert.Equal(t, "job1", sender.deliveries[0].Event.Data)
+		assert.Equal(t, 1, sender.deliveries[0].Attempt)
+	})
+
+	t.Run("failed deliveries are retried", func(t *testing.T) {
+		sender := &fakeSender{failures: 2}
+		webhooks := NewWebhooks(sender.send, nil, fastRetries, limits, time.Now)
+		_, err := webhooks.Subscribe(context.Background(), "https://example.com/hook", []string{EventJobCompleted}, "secret")
+		require.NoError(t, err)
+
+		webhooks.Publish(EventJobCompleted, "job1")
+		require.NoError(t, webhooks.Shutdown(context.Background()))
+
+		require.Len(t, sender.deliveries, 3)
+		assert.Equal(t, sender.deliveries[0].ID, sender.deliveries[2].ID)
+		assert.Equal(t, 3, sender.deliveries[2].Attempt)
+		assert.Empty(t, webhooks.DeadLetters(context.Background()))
+	})
+
+	t.Run("deliveries are given up after the last attempt", func(t *testing.T) {
+		sender := &fakeSender{failures: 3}
+		webhooks := NewWebhooks(sender.send, nil, fastRetries, WebhookLimits{MaxSubscriptions: 10, Workers: 2, MaxQueued: 10, MaxDeadLetters: 2}, time.Now)
+		_, err := webhooks.Subscribe(context.Background(), "https://example.com/hook", []string{EventJobCompleted}, "secret")
+		require.NoError(t, err)
+
+		for _, job := range []string{"job1", "job2", "job3"} {
+			webhooks.Publish(EventJobCompleted, job)
+		}
+		require.NoError(t, webhooks.Shutdown(context.Background()))
+
+		assert.Len(t, sender.deliveries, 9)
+		deadLetters := webhooks.DeadLetters(context.Background())
+		require.Len(t, deadLetters, 2)
+		for _, dl := range deadLetters {
+			assert.Equal(t, 3, dl.Delivery.Attempt)
+			assert.Equal(t, "unexpected response status 500", dl.Err)
+		}
+	})
+
+	t.Run("deliveries left are given up when shutdown times out", func(t *testing.T) {
+		sender := &fakeSender{failures: 1}
+		webhooks := NewWebhooks(sender.send, nil, WebhookRetryPolicy{MaxAttempts: 3, Backoff: time.Hour, MaxBackoff: time.Hour}, limits, time.Now)
+		_, err := webhooks.Subscribe(context.Background(), "https://example.com/hook", []string{EventJobCompleted}, "secret")
+		require.NoError(t, err)
+
+		webhooks.Publish(EventJobCompleted, "job1")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, webhooks.Shutdown(ctx), context.DeadlineExceeded)
+
+		deadLetters := webhooks.DeadLetters(context.Background())
+		require.Len(t, deadLetters, 1)
+		assert.Equal(t, 1, deadLetters[0].Delivery.Attempt)
+		assert.Equal(t, "canceled at shutdown after: unexpected response status 500", deadLetters[0].Err)
+
+		// events published once shut down are dropped
+		webhooks.Publish(EventJobCompleted, "job2")
+		assert.Len(t, sender.deliveries, 1)
+	})
+}
+
+func TestWebhooksWorkers(t *testing.T) {
+	t.Run("deliveries are made by the workers only", func(t *testing.T) {
+		sender := &blockingSender{started: make(chan struct{}), release: make(chan struct{})}
+		webhooks := NewWebhooks(sender.send, nil, fastRetries, limits, time.Now)
+		for i := range 5 {
+			_, err := webhooks.Subscribe(context.Background(), fmt.Sprintf("https://example.com/hook%d", i), []string{EventJobCompleted}, "")
+			require.NoError(t, err)
+		}
+
+		webhooks.Publish(EventJobCompleted, "job1")
+		// a delivery starts each time one of the busy workers is released
+		for range limits.Workers {
+			<-sender.started
+		}
+		for range 5 - limits.Workers {
+			sender.release <- struct{}{}
+			<-sender.started
+		}
+		for range limits.Workers {
+			sender.release <- struct{}{}
+		}
+		require.NoError(t, webhooks.Shutdown(context.Background()))
+
+		assert.Equal(t, limits.Workers, sender.maxSeen)
+		assert.Empty(t, webhooks.DeadLetters(context.Background()))
+	})
+
+	t.Run("deliveries finding the queue full are given up", func(t *testing.T) {
+		sender := &blockingSender{started: make(chan struct{}), release: make(chan struct{})}
+		webhooks := NewWebhooks(sender.send, nil, fastRetries, WebhookLimits{MaxSubscriptions: 10, Workers: 1, MaxQueued: 1, MaxDeadLetters: 10}, time.Now)
+
+		// the only worker is busy
+		_, err := webhooks.Subscribe(context.Background(), "https://example.com/canceled", []string{EventJobCanceled}, "")
+		require.NoError(t, err)
+		webhooks.Publish(EventJobCanceled, "job1")
+		<-sender.started
+
+		for _, url := range []string{"https://example.com/hook1", "https://example.com/hook2"} {
+			_, err := webhooks.Subscribe(context.Background(), url, []string{EventJobCompleted}, "")
+			require.NoError(t, err)
+		}
+		webhooks.Publish(EventJobCompleted, "job2")
+
+		deadLetters := webhooks.DeadLetters(context.Background())
+		require.Len(t, deadLetters, 1)
+		assert.Equal(t, "the delivery queue is full", deadLetters[0].Err)
+		assert.Zero(t, deadLetters[0].Delivery.Attempt)
+
+		sender.release <- struct{}{}
+		<-sender.started
+		sender.release <- struct{}{}
+		require.NoError(t, webhooks.Shutdown(context.Background()))
+	})
+}
+
+func TestWebhookRetryPolicyDelay(t *testing.T) {
+	policy := WebhookRetryPolicy{MaxAttempts: 10, Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 9: 5 * time.Second} {
+		assert.Equal(t, want, policy.delay(attempt), "attempt %d", attempt)
+	}
+}
@@ -0,0 +1,36 @@
+// Package auth restricts endpoints to the requests with a bearer token.
+package auth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RequireToken serves next only to the requests with the bearer token, the others being refused with writeError.
+// Every request is forbidden when token is empty, so that endpoints are disabled until a token is configured.
+// name names the endpoints and their token in the messages, e.g. "admin".
+func RequireToken(
+	token string,
+	name string,
+	writeError func(w http.ResponseWriter, r *http.Request, status int, message string),
+	next http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			writeError(w, r, http.StatusForbidden,
+				fmt.Sprintf("%s endpoints are disabled: no %s token is configured", name, name))
+			return
+		}
+
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="pokedex-api %s"`, name))
+			writeError(w, r, http.StatusUnauthorized, fmt.Sprintf("missing or invalid %s token", name))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,59 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fprojetto/pokedex-api/pkg/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	writeError := func(w http.ResponseWriter, r *http.Request, status int, message string) {
+		http.Error(w, message, status)
+	}
+
+	tests := []struct {
+		name                    string
+		token                   string
+		authorization           string
+		expectedStatus          int
+		expectedBody            string
+		expectedWWWAuthenticate string
+	}{
+		{name: "valid token", token: "s3cret", authorization: "Bearer s3cret", expectedStatus: http.StatusNoContent},
+		{
+			name: "invalid token", token: "s3cret", authorization: "Bearer guess",
+			expectedStatus: http.StatusUnauthorized, expectedBody: "missing or invalid debug token\n",
+			expectedWWWAuthenticate: `Bearer realm="pokedex-api debug"`,
+		},
+		{
+			name: "not a bearer token", token: "s3cret", authorization: "Basic s3cret",
+			expectedStatus: http.StatusUnauthorized, expectedBody: "missing or invalid debug token\n",
+			expectedWWWAuthenticate: `Bearer realm="pokedex-api debug"`,
+		},
+		{
+			name: "no token configured", authorization: "Bearer ",
+			expectedStatus: http.StatusForbidden, expectedBody: "debug endpoints are disabled: no debug token is configured\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/debug/vars", nil)
+			req.Header.Set("Authorization", tt.authorization)
+			res := httptest.NewRecorder()
+			auth.RequireToken(tt.token, "debug", writeError, ok).ServeHTTP(res, req)
+
+			assert.Equal(t, tt.expectedStatus, res.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, res.Body.String())
+			}
+			assert.Equal(t, tt.expectedWWWAuthenticate, res.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
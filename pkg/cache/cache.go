@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache is an in-memory key/value store whose entries expire after a fixed TTL.
+// When maxEntries is reached, expired entries are dropped first and then the entry closest to expiry.
+type Cache[K comparable, V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	items      map[K]entry[V]
+	now        func() time.Time
+}
+
+func New[K comparable, V any](ttl time.Duration, maxEntries int) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		items:      make(map[K]entry[V]),
+		now:        time.Now,
+	}
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if c.now().After(e.expiresAt) {
+		delete(c.items, key)
+		var zero V
+		return zero, false
+	}
+
+	return e.value, true
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 || c.maxEntries <= 0 {
+		return
+	}
+
+	if _, ok := c.items[key]; !ok && len(c.items) >= c.maxEntries {
+		c.evict()
+	}
+	c.items[key] = entry[V]{value: value, expiresAt: c.now().Add(c.ttl)}
+}
+
+// SetTTL changes the TTL of the entries set from now on, the others keep their expiry.
+func (c *Cache[K, V]) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// Clear removes all the entries.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.items)
+}
+
+// Len returns the number of entries, expired ones included until they are evicted.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// evict must be called with the lock held.
+func (c *Cache[K, V]) evict() {
+	now := c.now()
+	var oldestKey K
+	var oldest time.Time
+	first := true
+	for k, e := range c.items {
+		if now.After(e.expiresAt) {
+			delete(c.items, k)
+			continue
+		}
+		if first || e.expiresAt.Before(oldest) {
+			oldestKey, oldest, first = k, e.expiresAt, false
+		}
+	}
+
+	if len(c.items) >= c.maxEntries && !first {
+		delete(c.items, oldestKey)
+	}
+}
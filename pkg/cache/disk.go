@@ -0,0 +1,245 @@
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Blob is a binary value stored in a DiskCache along with its content type.
+type Blob struct {
+	ContentType string
+	ModTime     time.Time
+	Data        []byte
+}
+
+type blobMeta struct {
+	ContentType string    `json:"content_type"`
+	ModTime     time.Time `json:"mod_time"`
+}
+
+type diskEntry struct {
+	name string
+	size int64
+}
+
+// DiskCache stores blobs as files in a directory, evicting the least recently used
+// ones when the total size goes over maxBytes. Entries found in the directory at
+// creation are reused, so the cache survives restarts.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	size    int64
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating cache dir: %w", err)
+	}
+
+	c := &DiskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *DiskCache) Get(key string) (Blob, bool) {
+	name := fileName(key)
+
+	c.mu.Lock()
+	el, ok := c.entries[name]
+	if ok {
+		c.lru.MoveToFront(el)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return Blob{}, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, name+".bin"))
+	if err != nil {
+		c.remove(name)
+		return Blob{}, false
+	}
+	rawMeta, err := os.ReadFile(filepath.Join(c.dir, name+".json"))
+	if err != nil {
+		c.remove(name)
+		return Blob{}, false
+	}
+	var meta blobMeta
+	if err := json.Unmarshal(rawMeta, &meta); err != nil {
+		c.remove(name)
+		return Blob{}, false
+	}
+
+	return Blob{ContentType: meta.ContentType, ModTime: meta.ModTime, Data: data}, true
+}
+
+// Set stores the blob, blobs bigger than the whole cache are ignored.
+func (c *DiskCache) Set(key string, b Blob) error {
+	size := int64(len(b.Data))
+	if size > c.maxBytes {
+		return nil
+	}
+
+	name := fileName(key)
+	rawMeta, err := json.Marshal(blobMeta{ContentType: b.ContentType, ModTime: b.ModTime})
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(c.dir, name+".json"), rawMeta); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(c.dir, name+".bin"), b.Data); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[name]; ok {
+		c.size -= el.Value.(*diskEntry).size
+		el.Value.(*diskEntry).size = size
+		c.lru.MoveToFront(el)
+	} else {
+		c.entries[name] = c.lru.PushFront(&diskEntry{name: name, size: size})
+	}
+	c.size += size
+	c.evict()
+
+	return nil
+}
+
+// Clear removes all the blobs.
+func (c *DiskCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name := range c.entries {
+		removeFiles(c.dir, name)
+	}
+	clear(c.entries)
+	c.lru.Init()
+	c.size = 0
+}
+
+// Size returns the total size in bytes of the cached blobs.
+func (c *DiskCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// evict must be called with the lock held.
+func (c *DiskCache) evict() {
+	for c.size > c.maxBytes {
+		el := c.lru.Back()
+		if el == nil {
+			return
+		}
+		e := el.Value.(*diskEntry)
+		c.lru.Remove(el)
+		delete(c.entries, e.name)
+		c.size -= e.size
+		removeFiles(c.dir, e.name)
+	}
+}
+
+func (c *DiskCache) remove(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[name]; ok {
+		c.size -= el.Value.(*diskEntry).size
+		c.lru.Remove(el)
+		delete(c.entries, name)
+	}
+	removeFiles(c.dir, name)
+}
+
+// load indexes the blobs already in the directory, the most recently modified first.
+func (c *DiskCache) load() error {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("reading cache dir: %w", err)
+	}
+
+	type found struct {
+		entry   diskEntry
+		modTime time.Time
+	}
+	var blobs []found
+	for _, f := range files {
+		name, ok := strings.CutSuffix(f.Name(), ".bin")
+		if !ok || f.IsDir() {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(c.dir, name+".json")); errors.Is(err, os.ErrNotExist) {
+			removeFiles(c.dir, name)
+			continue
+		}
+		blobs = append(blobs, found{entry: diskEntry{name: name, size: info.Size()}, modTime: info.ModTime()})
+	}
+
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].modTime.After(blobs[j].modTime) })
+	for _, b := range blobs {
+		e := b.entry
+		c.entries[e.name] = c.lru.PushBack(&e)
+		c.size += e.size
+	}
+	c.evict()
+
+	return nil
+}
+
+func fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func removeFiles(dir, name string) {
+	_ = os.Remove(filepath.Join(dir, name+".bin"))
+	_ = os.Remove(filepath.Join(dir, name+".json"))
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func blob(size int) Blob {
+	return Blob{ContentType: "image/png", ModTime: time.Unix(0, 0).UTC(), Data: make([]byte, size)}
+}
+
+func TestDiskCache(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 1000)
+	require.NoError(t, err)
+
+	_, ok := c.Get("bulbasaur")
+	assert.False(t, ok)
+
+	b := Blob{ContentType: "image/png", ModTime: time.Unix(60, 0).UTC(), Data: []byte("bulbasaur")}
+	require.NoError(t, c.Set("bulbasaur", b))
+
+	got, ok := c.Get("bulbasaur")
+	require.True(t, ok)
+	assert.Equal(t, b.ContentType, got.ContentType)
+	assert.True(t, b.ModTime.Equal(got.ModTime))
+	assert.Equal(t, b.Data, got.Data)
+
+	require.NoError(t, c.Set("bulbasaur", blob(100)))
+	assert.Equal(t, int64(100), c.Size(), "overwriting must replace the size of the blob")
+}
+
+func TestDiskCacheEviction(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 1000)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("bulbasaur", blob(400)))
+	require.NoError(t, c.Set("ivysaur", blob(400)))
+	_, ok := c.Get("bulbasaur")
+	require.True(t, ok)
+	require.NoError(t, c.Set("venusaur", blob(400)))
+
+	assert.Equal(t, int64(800), c.Size())
+	_, ok = c.Get("ivysaur")
+	assert.False(t, ok, "least recently used blob must be evicted")
+	_, ok = c.Get("bulbasaur")
+	assert.True(t, ok, "reading a blob must mark it as recently used")
+	_, ok = c.Get("venusaur")
+	assert.True(t, ok)
+
+	for _, ext := range []string{".bin", ".json"} {
+		_, err := os.Stat(filepath.Join(dir, fileName("ivysaur")+ext))
+		assert.ErrorIs(t, err, os.ErrNotExist, "evicted blob files must be removed")
+	}
+}
+
+func TestDiskCacheMaxBytes(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 1000)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("bulbasaur", blob(1001)))
+	_, ok := c.Get("bulbasaur")
+	assert.False(t, ok, "blobs bigger than the cache must not be stored")
+	assert.Equal(t, int64(0), c.Size())
+
+	require.NoError(t, c.Set("ivysaur", blob(1000)))
+	assert.Equal(t, int64(1000), c.Size(), "a blob as big as the cache must fit")
+
+	require.NoError(t, c.Set("venusaur", blob(1)))
+	assert.Equal(t, int64(1), c.Size())
+	_, ok = c.Get("ivysaur")
+	assert.False(t, ok)
+}
+
+func TestDiskCacheLoad(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 1000)
+	require.NoError(t, err)
+
+	names := []string{"bulbasaur", "ivysaur", "venusaur"}
+	for i, name := range names {
+		require.NoError(t, c.Set(name, blob(300)))
+		modTime := time.Now().Add(time.Duration(i-len(names)) * time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, fileName(name)+".bin"), modTime, modTime))
+	}
+	// a blob without its metadata is left by a write interrupted by a crash
+	orphan := filepath.Join(dir, fileName("charmander")+".bin")
+	require.NoError(t, os.WriteFile(orphan, make([]byte, 100), 0o644))
+
+	reopened, err := NewDiskCache(dir, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(900), reopened.Size(), "disk cache must survive restarts")
+	_, err = os.Stat(orphan)
+	assert.ErrorIs(t, err, os.ErrNotExist, "blobs without metadata must be removed")
+	got, ok := reopened.Get("ivysaur")
+	require.True(t, ok)
+	assert.Equal(t, "image/png", got.ContentType)
+
+	shrunk, err := NewDiskCache(dir, 600)
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), shrunk.Size())
+	_, ok = shrunk.Get("bulbasaur")
+	assert.False(t, ok, "least recently modified blob must be evicted")
+	_, ok = shrunk.Get("ivysaur")
+	assert.True(t, ok)
+	_, ok = shrunk.Get("venusaur")
+	assert.True(t, ok)
+}
+
+func TestDiskCacheFailedWrite(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 1000)
+	require.NoError(t, err)
+	require.NoError(t, c.Set("bulbasaur", blob(400)))
+
+	require.NoError(t, os.RemoveAll(dir))
+
+	assert.Error(t, c.Set("ivysaur", blob(400)))
+	assert.Equal(t, int64(400), c.Size(), "a failed write must not be counted")
+	_, ok := c.Get("ivysaur")
+	assert.False(t, ok)
+	_, ok = c.Get("bulbasaur")
+	assert.False(t, ok, "blobs missing from the disk must be reported as misses")
+	assert.Equal(t, int64(0), c.Size(), "blobs missing from the disk must be dropped")
+}
+
+func TestDiskCacheClear(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 1000)
+	require.NoError(t, err)
+	require.NoError(t, c.Set("bulbasaur", blob(400)))
+
+	c.Clear()
+
+	assert.Equal(t, int64(0), c.Size())
+	_, ok := c.Get("bulbasaur")
+	assert.False(t, ok)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	require.NoError(t, c.Set("ivysaur", blob(400)))
+	assert.Equal(t, int64(400), c.Size())
+}
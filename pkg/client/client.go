@@ -3,28 +3,65 @@ package client
 import (
 	"net"
 	"net/http"
+	"syscall"
 	"time"
 )
 
-func HttpClient() *http.Client {
+// Config holds the timeouts and the connection pool size of a client, usually one per upstream.
+type Config struct {
+	// Timeout bounds a whole request: dial, TLS, sending the request, waiting for and reading the response.
+	Timeout time.Duration
+	// DialTimeout bounds establishing a TCP connection.
+	DialTimeout time.Duration
+	// TLSHandshakeTimeout bounds the HTTPS handshake.
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout bounds waiting for the response headers.
+	ResponseHeaderTimeout time.Duration
+	// IdleConnTimeout is how long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+	// MaxIdleConns is the maximum number of idle connections across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections to a single host (the default of net/http is 2!).
+	MaxIdleConnsPerHost int
+	// DialControl, when set, is called with the address of every connection before it is established,
+	// e.g. to refuse some addresses, see net.Dialer.Control.
+	DialControl func(network, address string, c syscall.RawConn) error
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:               30 * time.Second,
+		DialTimeout:           5 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   100,
+	}
+}
+
+// HttpClient returns a client configured by cfg. Its open connections are published by upstream address
+// in the upstream_connections expvar.
+func HttpClient(cfg Config) *http.Client {
 	// Define the Transport (Network Layer)
 	t := &http.Transport{
 		// 1. Connection Dialing settings
-		DialContext: (&net.Dialer{
-			Timeout:   5 * time.Second,  // Max time to establish a TCP connection
+		DialContext: countConns((&net.Dialer{
+			Timeout:   cfg.DialTimeout,
 			KeepAlive: 30 * time.Second, // Probe interval for active connections
-		}).DialContext,
+			Control:   cfg.DialControl,
+		}).DialContext),
 
 		// 2. TLS/SSL Handshake
-		TLSHandshakeTimeout: 10 * time.Second, // Max time for HTTPS handshake
+		TLSHandshakeTimeout: cfg.TLSHandshakeTimeout,
 
 		// 3. Connection Pooling (Very Important!)
-		MaxIdleConns:        100,              // Total max idle connections across all hosts
-		MaxIdleConnsPerHost: 100,              // Max idle connections for a SINGLE host (Default is 2!)
-		IdleConnTimeout:     90 * time.Second, // How long an idle connection is kept open
+		MaxIdleConns:        cfg.MaxIdleConns,
+		MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:     cfg.IdleConnTimeout,
 
 		// 4. Response Reading
-		ResponseHeaderTimeout: 10 * time.Second, // Max time to wait for server response headers
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
 	}
 
 	// Define the Client (High Level)
@@ -32,8 +69,7 @@ func HttpClient() *http.Client {
 		Transport: t,
 
 		// 5. Total Request Timeout
-		// This includes Dial, TLS, sending request, waiting for response, and reading body.
-		Timeout: 30 * time.Second,
+		Timeout: cfg.Timeout,
 	}
 
 	return client
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"expvar"
+	"net"
+	"sync"
+)
+
+// openConns counts the open connections of the clients, by upstream address, e.g. "pokeapi.co:443".
+var openConns = expvar.NewMap("upstream_connections")
+
+// dialFunc is the DialContext of a transport.
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// countConns counts the connections dialed by dial in openConns until they are closed.
+func countConns(dial dialFunc) dialFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		openConns.Add(addr, 1)
+		return &countedConn{Conn: conn, addr: addr}, nil
+	}
+}
+
+type countedConn struct {
+	net.Conn
+	addr string
+	once sync.Once
+}
+
+func (c *countedConn) Close() error {
+	// the transport may close a connection more than once
+	c.once.Do(func() { openConns.Add(c.addr, -1) })
+	return c.Conn.Close()
+}
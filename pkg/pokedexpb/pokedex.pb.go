@@ -0,0 +1,708 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pokedex/v1/pokedex.proto
+
+package pokedexpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetPokemonRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// include selects the optional sections of data: "types", "stats" and "abilities".
+	Include       []string `protobuf:"bytes,2,rep,name=include,proto3" json:"include,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPokemonRequest) Reset() {
+	*x = GetPokemonRequest{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPokemonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPokemonRequest) ProtoMessage() {}
+
+func (x *GetPokemonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPokemonRequest.ProtoReflect.Descriptor instead.
+func (*GetPokemonRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPokemonRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetPokemonRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type BatchGetPokemonRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Translated    bool                   `protobuf:"varint,2,opt,name=translated,proto3" json:"translated,omitempty"`
+	Include       []string               `protobuf:"bytes,3,rep,name=include,proto3" json:"include,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetPokemonRequest) Reset() {
+	*x = BatchGetPokemonRequest{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetPokemonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPokemonRequest) ProtoMessage() {}
+
+func (x *BatchGetPokemonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPokemonRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetPokemonRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BatchGetPokemonRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *BatchGetPokemonRequest) GetTranslated() bool {
+	if x != nil {
+		return x.Translated
+	}
+	return false
+}
+
+func (x *BatchGetPokemonRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type BatchGetPokemonResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*PokemonItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetPokemonResponse) Reset() {
+	*x = BatchGetPokemonResponse{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetPokemonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPokemonResponse) ProtoMessage() {}
+
+func (x *BatchGetPokemonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPokemonResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetPokemonResponse) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchGetPokemonResponse) GetItems() []*PokemonItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ListPokemonRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page_size is the number of pokemon fetched at once, capped by the server.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// expand hydrates every item with its pokemon, otherwise only names are sent.
+	Expand        bool     `protobuf:"varint,2,opt,name=expand,proto3" json:"expand,omitempty"`
+	Include       []string `protobuf:"bytes,3,rep,name=include,proto3" json:"include,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPokemonRequest) Reset() {
+	*x = ListPokemonRequest{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPokemonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPokemonRequest) ProtoMessage() {}
+
+func (x *ListPokemonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPokemonRequest.ProtoReflect.Descriptor instead.
+func (*ListPokemonRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPokemonRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPokemonRequest) GetExpand() bool {
+	if x != nil {
+		return x.Expand
+	}
+	return false
+}
+
+func (x *ListPokemonRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type PokemonItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Pokemon       *Pokemon               `protobuf:"bytes,2,opt,name=pokemon,proto3" json:"pokemon,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PokemonItem) Reset() {
+	*x = PokemonItem{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PokemonItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PokemonItem) ProtoMessage() {}
+
+func (x *PokemonItem) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PokemonItem.ProtoReflect.Descriptor instead.
+func (*PokemonItem) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PokemonItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PokemonItem) GetPokemon() *Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+func (x *PokemonItem) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type Error struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code is the error code of the HTTP API, e.g. "NOT_FOUND".
+	Code          string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Error) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Pokemon struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Habitat     string                 `protobuf:"bytes,4,opt,name=habitat,proto3" json:"habitat,omitempty"`
+	IsLegendary *bool                  `protobuf:"varint,5,opt,name=is_legendary,json=isLegendary,proto3,oneof" json:"is_legendary,omitempty"`
+	Types       []string               `protobuf:"bytes,6,rep,name=types,proto3" json:"types,omitempty"`
+	Stats       []*Stat                `protobuf:"bytes,7,rep,name=stats,proto3" json:"stats,omitempty"`
+	Abilities   []*Ability             `protobuf:"bytes,8,rep,name=abilities,proto3" json:"abilities,omitempty"`
+	Height      int32                  `protobuf:"varint,9,opt,name=height,proto3" json:"height,omitempty"`
+	Weight      int32                  `protobuf:"varint,10,opt,name=weight,proto3" json:"weight,omitempty"`
+	// unavailable lists the requested sections of data that could not be loaded.
+	Unavailable   []string `protobuf:"bytes,11,rep,name=unavailable,proto3" json:"unavailable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pokemon) Reset() {
+	*x = Pokemon{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pokemon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pokemon) ProtoMessage() {}
+
+func (x *Pokemon) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pokemon.ProtoReflect.Descriptor instead.
+func (*Pokemon) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Pokemon) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Pokemon) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pokemon) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Pokemon) GetHabitat() string {
+	if x != nil {
+		return x.Habitat
+	}
+	return ""
+}
+
+func (x *Pokemon) GetIsLegendary() bool {
+	if x != nil && x.IsLegendary != nil {
+		return *x.IsLegendary
+	}
+	return false
+}
+
+func (x *Pokemon) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Pokemon) GetStats() []*Stat {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *Pokemon) GetAbilities() []*Ability {
+	if x != nil {
+		return x.Abilities
+	}
+	return nil
+}
+
+func (x *Pokemon) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Pokemon) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Pokemon) GetUnavailable() []string {
+	if x != nil {
+		return x.Unavailable
+	}
+	return nil
+}
+
+type Stat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BaseStat      int32                  `protobuf:"varint,2,opt,name=base_stat,json=baseStat,proto3" json:"base_stat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stat) Reset() {
+	*x = Stat{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stat) ProtoMessage() {}
+
+func (x *Stat) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stat.ProtoReflect.Descriptor instead.
+func (*Stat) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Stat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Stat) GetBaseStat() int32 {
+	if x != nil {
+		return x.BaseStat
+	}
+	return 0
+}
+
+type Ability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	IsHidden      bool                   `protobuf:"varint,2,opt,name=is_hidden,json=isHidden,proto3" json:"is_hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ability) Reset() {
+	*x = Ability{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ability) ProtoMessage() {}
+
+func (x *Ability) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ability.ProtoReflect.Descriptor instead.
+func (*Ability) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Ability) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Ability) GetIsHidden() bool {
+	if x != nil {
+		return x.IsHidden
+	}
+	return false
+}
+
+var File_pokedex_v1_pokedex_proto protoreflect.FileDescriptor
+
+const file_pokedex_v1_pokedex_proto_rawDesc = "" +
+	"\n" +
+	"\x18pokedex/v1/pokedex.proto\x12\n" +
+	"pokedex.v1\"A\n" +
+	"\x11GetPokemonRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\ainclude\x18\x02 \x03(\tR\ainclude\"h\n" +
+	"\x16BatchGetPokemonRequest\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\x12\x1e\n" +
+	"\n" +
+	"translated\x18\x02 \x01(\bR\n" +
+	"translated\x12\x18\n" +
+	"\ainclude\x18\x03 \x03(\tR\ainclude\"H\n" +
+	"\x17BatchGetPokemonResponse\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.pokedex.v1.PokemonItemR\x05items\"c\n" +
+	"\x12ListPokemonRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06expand\x18\x02 \x01(\bR\x06expand\x12\x18\n" +
+	"\ainclude\x18\x03 \x03(\tR\ainclude\"y\n" +
+	"\vPokemonItem\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
+	"\apokemon\x18\x02 \x01(\v2\x13.pokedex.v1.PokemonR\apokemon\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.pokedex.v1.ErrorR\x05error\"5\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xe5\x02\n" +
+	"\aPokemon\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\ahabitat\x18\x04 \x01(\tR\ahabitat\x12&\n" +
+	"\fis_legendary\x18\x05 \x01(\bH\x00R\visLegendary\x88\x01\x01\x12\x14\n" +
+	"\x05types\x18\x06 \x03(\tR\x05types\x12&\n" +
+	"\x05stats\x18\a \x03(\v2\x10.pokedex.v1.StatR\x05stats\x121\n" +
+	"\tabilities\x18\b \x03(\v2\x13.pokedex.v1.AbilityR\tabilities\x12\x16\n" +
+	"\x06height\x18\t \x01(\x05R\x06height\x12\x16\n" +
+	"\x06weight\x18\n" +
+	" \x01(\x05R\x06weight\x12 \n" +
+	"\vunavailable\x18\v \x03(\tR\vunavailableB\x0f\n" +
+	"\r_is_legendary\"7\n" +
+	"\x04Stat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tbase_stat\x18\x02 \x01(\x05R\bbaseStat\":\n" +
+	"\aAbility\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tis_hidden\x18\x02 \x01(\bR\bisHidden2\xc4\x02\n" +
+	"\x0ePokedexService\x12@\n" +
+	"\n" +
+	"GetPokemon\x12\x1d.pokedex.v1.GetPokemonRequest\x1a\x13.pokedex.v1.Pokemon\x12J\n" +
+	"\x14GetPokemonTranslated\x12\x1d.pokedex.v1.GetPokemonRequest\x1a\x13.pokedex.v1.Pokemon\x12Z\n" +
+	"\x0fBatchGetPokemon\x12\".pokedex.v1.BatchGetPokemonRequest\x1a#.pokedex.v1.BatchGetPokemonResponse\x12H\n" +
+	"\vListPokemon\x12\x1e.pokedex.v1.ListPokemonRequest\x1a\x17.pokedex.v1.PokemonItem0\x01B0Z.github.com/fprojetto/pokedex-api/pkg/pokedexpbb\x06proto3"
+
+var (
+	file_pokedex_v1_pokedex_proto_rawDescOnce sync.Once
+	file_pokedex_v1_pokedex_proto_rawDescData []byte
+)
+
+func file_pokedex_v1_pokedex_proto_rawDescGZIP() []byte {
+	file_pokedex_v1_pokedex_proto_rawDescOnce.Do(func() {
+		file_pokedex_v1_pokedex_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pokedex_v1_pokedex_proto_rawDesc), len(file_pokedex_v1_pokedex_proto_rawDesc)))
+	})
+	return file_pokedex_v1_pokedex_proto_rawDescData
+}
+
+var file_pokedex_v1_pokedex_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pokedex_v1_pokedex_proto_goTypes = []any{
+	(*GetPokemonRequest)(nil),       // 0: pokedex.v1.GetPokemonRequest
+	(*BatchGetPokemonRequest)(nil),  // 1: pokedex.v1.BatchGetPokemonRequest
+	(*BatchGetPokemonResponse)(nil), // 2: pokedex.v1.BatchGetPokemonResponse
+	(*ListPokemonRequest)(nil),      // 3: pokedex.v1.ListPokemonRequest
+	(*PokemonItem)(nil),             // 4: pokedex.v1.PokemonItem
+	(*Error)(nil),                   // 5: pokedex.v1.Error
+	(*Pokemon)(nil),                 // 6: pokedex.v1.Pokemon
+	(*Stat)(nil),                    // 7: pokedex.v1.Stat
+	(*Ability)(nil),                 // 8: pokedex.v1.Ability
+}
+var file_pokedex_v1_pokedex_proto_depIdxs = []int32{
+	4, // 0: pokedex.v1.BatchGetPokemonResponse.items:type_name -> pokedex.v1.PokemonItem
+	6, // 1: pokedex.v1.PokemonItem.pokemon:type_name -> pokedex.v1.Pokemon
+	5, // 2: pokedex.v1.PokemonItem.error:type_name -> pokedex.v1.Error
+	7, // 3: pokedex.v1.Pokemon.stats:type_name -> pokedex.v1.Stat
+	8, // 4: pokedex.v1.Pokemon.abilities:type_name -> pokedex.v1.Ability
+	0, // 5: pokedex.v1.PokedexService.GetPokemon:input_type -> pokedex.v1.GetPokemonRequest
+	0, // 6: pokedex.v1.PokedexService.GetPokemonTranslated:input_type -> pokedex.v1.GetPokemonRequest
+	1, // 7: pokedex.v1.PokedexService.BatchGetPokemon:input_type -> pokedex.v1.BatchGetPokemonRequest
+	3, // 8: pokedex.v1.PokedexService.ListPokemon:input_type -> pokedex.v1.ListPokemonRequest
+	6, // 9: pokedex.v1.PokedexService.GetPokemon:output_type -> pokedex.v1.Pokemon
+	6, // 10: pokedex.v1.PokedexService.GetPokemonTranslated:output_type -> pokedex.v1.Pokemon
+	2, // 11: pokedex.v1.PokedexService.BatchGetPokemon:output_type -> pokedex.v1.BatchGetPokemonResponse
+	4, // 12: pokedex.v1.PokedexService.ListPokemon:output_type -> pokedex.v1.PokemonItem
+	9, // [9:13] is the sub-list for method output_type
+	5, // [5:9] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_pokedex_v1_pokedex_proto_init() }
+func file_pokedex_v1_pokedex_proto_init() {
+	if File_pokedex_v1_pokedex_proto != nil {
+		return
+	}
+	file_pokedex_v1_pokedex_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pokedex_v1_pokedex_proto_rawDesc), len(file_pokedex_v1_pokedex_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pokedex_v1_pokedex_proto_goTypes,
+		DependencyIndexes: file_pokedex_v1_pokedex_proto_depIdxs,
+		MessageInfos:      file_pokedex_v1_pokedex_proto_msgTypes,
+	}.Build()
+	File_pokedex_v1_pokedex_proto = out.File
+	file_pokedex_v1_pokedex_proto_goTypes = nil
+	file_pokedex_v1_pokedex_proto_depIdxs = nil
+}
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: pokedex/v1/pokedex.proto
+
+package pokedexpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PokedexService_GetPokemon_FullMethodName           = "/pokedex.v1.PokedexService/GetPokemon"
+	PokedexService_GetPokemonTranslated_FullMethodName = "/pokedex.v1.PokedexService/GetPokemonTranslated"
+	PokedexService_BatchGetPokemon_FullMethodName      = "/pokedex.v1.PokedexService/BatchGetPokemon"
+	PokedexService_ListPokemon_FullMethodName          = "/pokedex.v1.PokedexService/ListPokemon"
+)
+
+// PokedexServiceClient is the client API for PokedexService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PokedexService serves the pokemon of the HTTP API to internal services.
+// Errors are reported with the gRPC status codes: NOT_FOUND, INVALID_ARGUMENT, UNAVAILABLE when an
+// upstream API is down, INTERNAL otherwise. Every response carries an x-request-id header, taken from
+// the x-request-id metadata of the request when set.
+type PokedexServiceClient interface {
+	// GetPokemon returns a pokemon by name or national pokedex ID.
+	GetPokemon(ctx context.Context, in *GetPokemonRequest, opts ...grpc.CallOption) (*Pokemon, error)
+	// GetPokemonTranslated returns a pokemon with its description translated.
+	GetPokemonTranslated(ctx context.Context, in *GetPokemonRequest, opts ...grpc.CallOption) (*Pokemon, error)
+	// BatchGetPokemon returns several pokemon at once, each item has either the pokemon or its error.
+	BatchGetPokemon(ctx context.Context, in *BatchGetPokemonRequest, opts ...grpc.CallOption) (*BatchGetPokemonResponse, error)
+	// ListPokemon streams every pokemon, in pokedex order, until the end of the listing or the client cancels.
+	ListPokemon(ctx context.Context, in *ListPokemonRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PokemonItem], error)
+}
+
+type pokedexServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPokedexServiceClient(cc grpc.ClientConnInterface) PokedexServiceClient {
+	return &pokedexServiceClient{cc}
+}
+
+func (c *pokedexServiceClient) GetPokemon(ctx context.Context, in *GetPokemonRequest, opts ...grpc.CallOption) (*Pokemon, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Pokemon)
+	err := c.cc.Invoke(ctx, PokedexService_GetPokemon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokedexServiceClient) GetPokemonTranslated(ctx context.Context, in *GetPokemonRequest, opts ...grpc.CallOption) (*Pokemon, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Pokemon)
+	err := c.cc.Invoke(ctx, PokedexService_GetPokemonTranslated_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokedexServiceClient) BatchGetPokemon(ctx context.Context, in *BatchGetPokemonRequest, opts ...grpc.CallOption) (*BatchGetPokemonResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetPokemonResponse)
+	err := c.cc.Invoke(ctx, PokedexService_BatchGetPokemon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokedexServiceClient) ListPokemon(ctx context.Context, in *ListPokemonRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PokemonItem], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PokedexService_ServiceDesc.Streams[0], PokedexService_ListPokemon_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListPokemonRequest, PokemonItem]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PokedexService_ListPokemonClient = grpc.ServerStreamingClient[PokemonItem]
+
+// PokedexServiceServer is the server API for PokedexService service.
+// All implementations must embed UnimplementedPokedexServiceServer
+// for forward compatibility.
+//
+// PokedexService serves the pokemon of the HTTP API to internal services.
+// Errors are reported with the gRPC status codes: NOT_FOUND, INVALID_ARGUMENT, UNAVAILABLE when an
+// upstream API is down, INTERNAL otherwise. Every response carries an x-request-id header, taken from
+// the x-request-id metadata of the request when set.
+type PokedexServiceServer interface {
+	// GetPokemon returns a pokemon by name or national pokedex ID.
+	GetPokemon(context.Context, *GetPokemonRequest) (*Pokemon, error)
+	// GetPokemonTranslated returns a pokemon with its description translated.
+	GetPokemonTranslated(context.Context, *GetPokemonRequest) (*Pokemon, error)
+	// BatchGetPokemon returns several pokemon at once, each item has either the pokemon or its error.
+	BatchGetPokemon(context.Context, *BatchGetPokemonRequest) (*BatchGetPokemonResponse, error)
+	// ListPokemon streams every pokemon, in pokedex order, until the end of the listing or the client cancels.
+	ListPokemon(*ListPokemonRequest, grpc.ServerStreamingServer[PokemonItem]) error
+	mustEmbedUnimplementedPokedexServiceServer()
+}
+
+// UnimplementedPokedexServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPokedexServiceServer struct{}
+
+func (UnimplementedPokedexServiceServer) GetPokemon(context.Context, *GetPokemonRequest) (*Pokemon, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPokemon not implemented")
+}
+func (UnimplementedPokedexServiceServer) GetPokemonTranslated(context.Context, *GetPokemonRequest) (*Pokemon, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPokemonTranslated not implemented")
+}
+func (UnimplementedPokedexServiceServer) BatchGetPokemon(context.Context, *BatchGetPokemonRequest) (*BatchGetPokemonResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetPokemon not implemented")
+}
+func (UnimplementedPokedexServiceServer) ListPokemon(*ListPokemonRequest, grpc.ServerStreamingServer[PokemonItem]) error {
+	return status.Error(codes.Unimplemented, "method ListPokemon not implemented")
+}
+func (UnimplementedPokedexServiceServer) mustEmbedUnimplementedPokedexServiceServer() {}
+func (UnimplementedPokedexServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePokedexServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PokedexServiceServer will
+// result in compilation errors.
+type UnsafePokedexServiceServer interface {
+	mustEmbedUnimplementedPokedexServiceServer()
+}
+
+func RegisterPokedexServiceServer(s grpc.ServiceRegistrar, srv PokedexServiceServer) {
+	// If the following call panics, it indicates UnimplementedPokedexServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PokedexService_ServiceDesc, srv)
+}
+
+func _PokedexService_GetPokemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPokemonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokedexServiceServer).GetPokemon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokedexService_GetPokemon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokedexServiceServer).GetPokemon(ctx, req.(*GetPokemonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokedexService_GetPokemonTranslated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPokemonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokedexServiceServer).GetPokemonTranslated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokedexService_GetPokemonTranslated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokedexServiceServer).GetPokemonTranslated(ctx, req.(*GetPokemonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokedexService_BatchGetPokemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetPokemonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokedexServiceServer).BatchGetPokemon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokedexService_BatchGetPokemon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokedexServiceServer).BatchGetPokemon(ctx, req.(*BatchGetPokemonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokedexService_ListPokemon_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListPokemonRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PokedexServiceServer).ListPokemon(m, &grpc.GenericServerStream[ListPokemonRequest, PokemonItem]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PokedexService_ListPokemonServer = grpc.ServerStreamingServer[PokemonItem]
+
+// PokedexService_ServiceDesc is the grpc.ServiceDesc for PokedexService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PokedexService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pokedex.v1.PokedexService",
+	HandlerType: (*PokedexServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPokemon",
+			Handler:    _PokedexService_GetPokemon_Handler,
+		},
+		{
+			MethodName: "GetPokemonTranslated",
+			Handler:    _PokedexService_GetPokemonTranslated_Handler,
+		},
+		{
+			MethodName: "BatchGetPokemon",
+			Handler:    _PokedexService_BatchGetPokemon_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListPokemon",
+			Handler:       _PokedexService_ListPokemon_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pokedex/v1/pokedex.proto",
+}
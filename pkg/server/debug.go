@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/fprojetto/pokedex-api/pkg/auth"
+)
+
+// DebugConfig configures the debug endpoints of the ops mux: the profiles of net/http/pprof under /debug/pprof/,
+// and the runtime stats and the expvars of the process under /debug/vars.
+type DebugConfig struct {
+	Enabled bool
+	// Token is the bearer token of the debug endpoints, every request being forbidden when it is empty.
+	Token string
+}
+
+var publishRuntimeStats sync.Once
+
+// newDebugMux routes the debug endpoints, behind the bearer token.
+func newDebugMux(token string) http.Handler {
+	publishRuntimeStats.Do(func() {
+		expvar.Publish("runtime", expvar.Func(runtimeStats))
+	})
+
+	mux := http.NewServeMux()
+	// pprof.Cmdline is left out, as the command line may hold secrets
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/vars", debugVars)
+	return auth.RequireToken(token, "debug", func(w http.ResponseWriter, _ *http.Request, status int, message string) {
+		http.Error(w, message, status)
+	}, mux)
+}
+
+// debugVars serves the expvars of the process as a JSON object, like expvar.Handler, but for the command line.
+func debugVars(w http.ResponseWriter, _ *http.Request) {
+	vars := make(map[string]json.RawMessage)
+	expvar.Do(func(kv expvar.KeyValue) {
+		if kv.Key != "cmdline" {
+			vars[kv.Key] = json.RawMessage(kv.Value.String())
+		}
+	})
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(vars)
+}
+
+// runtimeStats are the goroutines, garbage collector and heap stats of the runtime, published as the runtime expvar.
+func runtimeStats() any {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	var lastGC time.Time
+	if m.LastGC > 0 {
+		lastGC = time.Unix(0, int64(m.LastGC))
+	}
+	return map[string]any{
+		"goroutines": runtime.NumGoroutine(),
+		"gc": map[string]any{
+			"cycles":         m.NumGC,
+			"last":           lastGC,
+			"last_pause_ns":  m.PauseNs[(m.NumGC+255)%256],
+			"pause_total_ns": m.PauseTotalNs,
+			"cpu_fraction":   m.GCCPUFraction,
+			"next_heap_goal": m.NextGC,
+		},
+		"heap": map[string]any{
+			"alloc_bytes":    m.HeapAlloc,
+			"in_use_bytes":   m.HeapInuse,
+			"idle_bytes":     m.HeapIdle,
+			"released_bytes": m.HeapReleased,
+			"sys_bytes":      m.HeapSys,
+			"objects":        m.HeapObjects,
+		},
+	}
+}
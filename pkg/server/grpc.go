@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"log"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// RequestIDMetadataKey is the metadata key carrying the request ID of gRPC calls, both ways.
+const RequestIDMetadataKey = "x-request-id"
+
+type GRPCServer interface {
+	Run(ctx context.Context) error
+}
+
+type grpcServer struct {
+	srv    *grpc.Server
+	health *health.Server
+	ln     net.Listener
+	cfg    ServerConfig
+}
+
+// NewGRPCServer listens on cfg.Addr for the services registered by register. The server also
+// serves the standard health service and propagates request IDs, see RequestIDMetadataKey.
+// With cfg.TLS enabled, the identity of the clients is available as for http, see ClientIdentityFromContext.
+func NewGRPCServer(cfg ServerConfig, register func(s *grpc.Server)) (GRPCServer, error) {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(requestIDUnaryInterceptor),
+		grpc.ChainStreamInterceptor(requestIDStreamInterceptor),
+	}
+	if cfg.TLS.Enabled() {
+		tlsCfg, err := newTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+
+	if cfg.Name == "" {
+		cfg.Name = "grpc"
+	}
+	ln, err := listen(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := grpc.NewServer(opts...)
+	register(srv)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthServer)
+	for name := range srv.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	return &grpcServer{
+		srv:    srv,
+		health: healthServer,
+		ln:     ln,
+		cfg:    cfg,
+	}, nil
+}
+
+// Run serves until ctx is done, then stops gracefully within the shutdown timeout.
+func (s *grpcServer) Run(ctx context.Context) error {
+	releaseInherited()
+	defer listeners.remove(s.ln)
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("grpc server listening on %s", s.ln.Addr())
+		errCh <- s.srv.Serve(s.ln)
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-errCh:
+		return err
+	}
+
+	log.Println("shutting down grpc server")
+	// health checks fail first, so that clients stop sending new calls
+	s.health.Shutdown()
+
+	sdCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		var wg sync.WaitGroup
+		if s.cfg.OnShutdown != nil {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.cfg.OnShutdown(sdCtx)
+			}()
+		}
+		s.srv.GracefulStop()
+		wg.Wait()
+	}()
+
+	select {
+	case <-stopped:
+	case <-sdCtx.Done():
+		// streams still open are cut
+		s.srv.Stop()
+		<-stopped
+	}
+	return <-errCh
+}
+
+// withCallRequestID returns ctx carrying the request ID of the call, taken from its metadata or generated,
+// and sends it back as a header. The identity of the client is added too, if any.
+func withCallRequestID(ctx context.Context) context.Context {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDMetadataKey); len(ids) > 0 {
+			id = ids[0]
+		}
+	}
+	if id == "" {
+		id = rand.Text()
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, id))
+	ctx = WithRequestID(ctx, id)
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			ctx = withClientIdentity(ctx, &info.State)
+		}
+	}
+	return ctx
+}
+
+func requestIDUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	return handler(withCallRequestID(ctx), req)
+}
+
+func requestIDStreamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &requestIDStream{ServerStream: ss, ctx: withCallRequestID(ss.Context())})
+}
+
+// requestIDStream overrides the context of a stream with the one carrying the request ID.
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context {
+	return s.ctx
+}
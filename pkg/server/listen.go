@@ -0,0 +1,304 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// The environment passing listeners to a process, as set by systemd socket activation. A graceful restart
+// sets LISTEN_PPID instead of LISTEN_PID, as the process id of the child is not known before it starts,
+// and LISTEN_READY_FD, the pipe written to once the child has taken the listeners it uses, see releaseInherited.
+const (
+	listenFDsEnv     = "LISTEN_FDS"
+	listenFDNamesEnv = "LISTEN_FDNAMES"
+	listenPIDEnv     = "LISTEN_PID"
+	listenPPIDEnv    = "LISTEN_PPID"
+	listenReadyFDEnv = "LISTEN_READY_FD"
+
+	// listenFDsStart is the first file descriptor passed, after stdin, stdout and stderr.
+	listenFDsStart = 3
+
+	// restartTimeout bounds how long the new process of a graceful restart takes to be ready.
+	restartTimeout = 30 * time.Second
+)
+
+// listen returns the listener of a server: the one inherited under cfg.Name if any, see ServerConfig.Name,
+// or a new one on cfg.Addr. The listener is registered to be passed on at a graceful restart.
+func listen(cfg ServerConfig) (net.Listener, error) {
+	ln, err := inheritedListener(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if ln == nil {
+		switch cfg.Network {
+		case "", "tcp":
+			ln, err = net.Listen("tcp", cfg.Addr)
+		case "unix":
+			ln, err = listenUnix(cfg.Addr, cfg.SocketMode)
+		default:
+			err = fmt.Errorf("unsupported network %s", cfg.Network)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen: %w", err)
+		}
+	}
+
+	listeners.add(cfg.Name, ln)
+	return ln, nil
+}
+
+// listenUnix listens on the unix socket path, whose permissions are set to mode.
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if fi, err := os.Stat(path); err == nil && fi.Mode().Type() == os.ModeSocket {
+		// left behind by a process that did not stop gracefully, unless it is still served
+		if conn, err := net.Dial("unix", path); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("unix socket %s is in use", path)
+		}
+		_ = os.Remove(path)
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("failed to set the permissions of the unix socket: %w", err)
+	}
+	return ln, nil
+}
+
+var inherited struct {
+	mu    sync.Mutex
+	read  bool
+	files map[string]*os.File
+	// ready is written to once the files are taken, at a graceful restart
+	ready *os.File
+}
+
+// inheritedListener returns the listener named name passed to the process, nil if none.
+// The environment passing them is read once and cleared, so that it is not passed on to child processes.
+func inheritedListener(name string) (net.Listener, error) {
+	inherited.mu.Lock()
+	defer inherited.mu.Unlock()
+
+	readInherited()
+
+	f, ok := inherited.files[name]
+	if !ok {
+		return nil, nil
+	}
+	// a listener is only used once
+	delete(inherited.files, name)
+	if len(inherited.files) == 0 {
+		signalReady()
+	}
+
+	defer f.Close()
+	ln, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use the inherited listener %s: %w", name, err)
+	}
+	return ln, nil
+}
+
+// releaseInherited closes the inherited listeners left untaken, e.g. when the configuration of the new process
+// of a graceful restart drops the ops listener, and tells the process passing them that this one is ready.
+// The servers take their listeners when they are created, so it is called when they start running.
+func releaseInherited() {
+	inherited.mu.Lock()
+	defer inherited.mu.Unlock()
+
+	readInherited()
+	for name, f := range inherited.files {
+		log.Printf("closing the inherited listener %s, which is not used", name)
+		_ = f.Close()
+	}
+	clear(inherited.files)
+	signalReady()
+}
+
+// readInherited reads the inherited files once, it must be called with the lock held.
+func readInherited() {
+	if !inherited.read {
+		inherited.files, inherited.ready = inheritedFiles()
+		inherited.read = true
+	}
+}
+
+// signalReady writes to the ready pipe if any, it must be called with the lock held.
+func signalReady() {
+	if inherited.ready != nil {
+		_, _ = inherited.ready.Write([]byte{1})
+		_ = inherited.ready.Close()
+		inherited.ready = nil
+	}
+}
+
+func inheritedFiles() (map[string]*os.File, *os.File) {
+	defer func() {
+		for _, env := range []string{listenFDsEnv, listenFDNamesEnv, listenPIDEnv, listenPPIDEnv, listenReadyFDEnv} {
+			_ = os.Unsetenv(env)
+		}
+	}()
+
+	n, err := strconv.Atoi(os.Getenv(listenFDsEnv))
+	if err != nil || n < 1 {
+		return nil, nil
+	}
+	// the environment may have been inherited by a process it is not meant for
+	if os.Getenv(listenPIDEnv) != strconv.Itoa(os.Getpid()) && os.Getenv(listenPPIDEnv) != strconv.Itoa(os.Getppid()) {
+		return nil, nil
+	}
+
+	var ready *os.File
+	if fd, err := strconv.Atoi(os.Getenv(listenReadyFDEnv)); err == nil {
+		syscall.CloseOnExec(fd)
+		ready = os.NewFile(uintptr(fd), "ready")
+	}
+
+	names := strings.Split(os.Getenv(listenFDNamesEnv), ":")
+	files := make(map[string]*os.File, n)
+	for i := range n {
+		fd := listenFDsStart + i
+		syscall.CloseOnExec(fd)
+
+		// the default name of systemd
+		name := "unknown"
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+		files[name] = os.NewFile(uintptr(fd), name)
+	}
+	return files, ready
+}
+
+// listeners are the listeners of the servers of the process, by name.
+var listeners registry
+
+type registry struct {
+	mu      sync.Mutex
+	entries []registryEntry
+}
+
+type registryEntry struct {
+	name string
+	ln   net.Listener
+}
+
+func (r *registry) add(name string, ln net.Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, registryEntry{name: name, ln: ln})
+}
+
+func (r *registry) remove(ln net.Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = slices.DeleteFunc(r.entries, func(e registryEntry) bool { return e.ln == ln })
+}
+
+// restart starts a new process running the same executable, with the same arguments and environment,
+// passing it the listeners of the servers, and waits for it to take them. The listeners keep accepting
+// connections in both processes until this one shuts down, so that no connection is refused meanwhile.
+func restart() (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+
+	listeners.mu.Lock()
+	defer listeners.mu.Unlock()
+
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+	var names []string
+	for _, e := range listeners.entries {
+		fl, ok := e.ln.(interface{ File() (*os.File, error) })
+		if !ok {
+			return 0, fmt.Errorf("listener %s cannot be passed on", e.name)
+		}
+		f, err := fl.File()
+		if err != nil {
+			return 0, fmt.Errorf("failed to pass on listener %s: %w", e.name, err)
+		}
+		files = append(files, f)
+		names = append(names, e.name)
+	}
+	if len(files) == 0 {
+		return 0, errors.New("no listener to pass on")
+	}
+
+	ready, readyWriter, err := os.Pipe()
+	if err != nil {
+		return 0, err
+	}
+	defer ready.Close()
+	defer readyWriter.Close()
+
+	env := slices.DeleteFunc(os.Environ(), func(kv string) bool {
+		return strings.HasPrefix(kv, "LISTEN_")
+	})
+	env = append(env,
+		listenFDsEnv+"="+strconv.Itoa(len(files)),
+		listenFDNamesEnv+"="+strings.Join(names, ":"),
+		listenPPIDEnv+"="+strconv.Itoa(os.Getpid()),
+		listenReadyFDEnv+"="+strconv.Itoa(listenFDsStart+len(files)),
+	)
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.ExtraFiles = append(files, readyWriter)
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("failed to start the new process: %w", err)
+	}
+	// only the new process keeps the pipe open, so that reading it ends if it stops
+	_ = readyWriter.Close()
+
+	if err := waitReady(ready, restartTimeout); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return 0, err
+	}
+
+	// the unix sockets are now served by the new process too, and must outlive this one
+	for _, e := range listeners.entries {
+		if ul, ok := e.ln.(*net.UnixListener); ok {
+			ul.SetUnlinkOnClose(false)
+		}
+	}
+
+	pid := cmd.Process.Pid
+	return pid, cmd.Process.Release()
+}
+
+// waitReady waits for the new process of a restart to write to ready.
+func waitReady(ready *os.File, timeout time.Duration) error {
+	if err := ready.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	if _, err := ready.Read(make([]byte, 1)); err != nil {
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return errors.New("the new process did not take the listeners in time")
+		}
+		return errors.New("the new process stopped before taking the listeners")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inheritFiles sets the listeners named names as inherited at a graceful restart, along with the ready pipe.
+func inheritFiles(t *testing.T, names ...string) (files map[string]*os.File, ready *os.File) {
+	t.Helper()
+
+	files = make(map[string]*os.File, len(names))
+	for _, name := range names {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		f, err := ln.(*net.TCPListener).File()
+		require.NoError(t, err)
+		require.NoError(t, ln.Close())
+		files[name] = f
+	}
+	ready, readyWriter, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ready.Close() })
+
+	inherited.mu.Lock()
+	defer inherited.mu.Unlock()
+	inherited.read = true
+	inherited.files = make(map[string]*os.File, len(files))
+	for name, f := range files {
+		inherited.files[name] = f
+	}
+	inherited.ready = readyWriter
+	t.Cleanup(func() {
+		inherited.mu.Lock()
+		defer inherited.mu.Unlock()
+		inherited.files, inherited.ready = nil, nil
+	})
+	return files, ready
+}
+
+func TestInheritedListener(t *testing.T) {
+	t.Run("ready once every listener is taken", func(t *testing.T) {
+		_, ready := inheritFiles(t, "api", "api-ops")
+
+		for _, name := range []string{"api", "api-ops"} {
+			ln, err := inheritedListener(name)
+			require.NoError(t, err)
+			require.NotNil(t, ln)
+			_ = ln.Close()
+		}
+
+		require.NoError(t, waitReady(ready, time.Second))
+	})
+
+	t.Run("unused listeners are closed and ready is signaled", func(t *testing.T) {
+		files, ready := inheritFiles(t, "api", "api-ops")
+
+		ln, err := inheritedListener("api")
+		require.NoError(t, err)
+		require.NotNil(t, ln)
+		defer ln.Close()
+
+		releaseInherited()
+
+		require.NoError(t, waitReady(ready, time.Second))
+		assert.ErrorIs(t, files["api-ops"].Close(), os.ErrClosed, "the unused listener must be closed")
+
+		ln, err = inheritedListener("api-ops")
+		assert.NoError(t, err)
+		assert.Nil(t, ln)
+	})
+
+	t.Run("not inherited", func(t *testing.T) {
+		inheritFiles(t, "api")
+
+		ln, err := inheritedListener("grpc")
+		assert.NoError(t, err)
+		assert.Nil(t, ln)
+	})
+}
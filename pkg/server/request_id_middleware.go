@@ -20,13 +20,18 @@ func RequestIDMiddleware(next http.Handler) http.Handler {
 		w.Header().Set("X-Request-ID", id)
 
 		// 3. Store in Context
-		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		ctx := WithRequestID(r.Context(), id)
 
 		// 4. Pass the modified context to the next handler
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// WithRequestID returns a copy of ctx carrying the request ID id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
 // Helper to retrieve the ID later
 func GetRequestID(ctx context.Context) string {
 	if id, ok := ctx.Value(requestIDKey).(string); ok {
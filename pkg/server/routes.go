@@ -4,14 +4,25 @@ import (
 	"net/http"
 )
 
-func newRouter(apiMux http.Handler) *http.ServeMux {
+// newRouter routes /api/ to apiMux, and the other paths to opsMux when withOps, see ServerConfig.OpsAddr.
+func newRouter(apiMux http.Handler, opsMux http.Handler, withOps bool) *http.ServeMux {
 	rootMux := http.NewServeMux()
 
 	rootMux.Handle("/api/", apiMux)
 
-	opsMux := http.NewServeMux()
-	opsMux.HandleFunc("/health", HealthCheckHandler)
-	rootMux.Handle("/", opsMux)
+	if withOps {
+		rootMux.Handle("/", opsMux)
+	}
 
 	return rootMux
 }
+
+// newOpsMux routes the endpoints of the operators, the debug ones included when enabled.
+func newOpsMux(debug DebugConfig) *http.ServeMux {
+	opsMux := http.NewServeMux()
+	opsMux.HandleFunc("/health", HealthCheckHandler)
+	if debug.Enabled {
+		opsMux.Handle("/debug/", newDebugMux(debug.Token))
+	}
+	return opsMux
+}
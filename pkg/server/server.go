@@ -2,11 +2,14 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -15,7 +18,44 @@ type ServerConfig struct {
 	Addr            string
 	ShutdownTimeout time.Duration
 
-	OnShutdown func()
+	// Network is "tcp", the default, or "unix", Addr being then the path of the socket, created with SocketMode.
+	Network    string
+	SocketMode os.FileMode
+	// Name identifies the listener of the server among those passed to the process, which is then used instead
+	// of listening on Addr: by systemd socket activation (the FileDescriptorName of the socket), or by the parent
+	// process at a graceful restart. It is "http" or "grpc" when empty.
+	Name string
+	// OpsAddr is the tcp address of the ops endpoints, e.g. /health, served with the api when empty.
+	// With a listener of their own, they are not exposed by the api listener, which only serves /api/.
+	OpsAddr string
+	// GracefulRestart re-executes the process on SIGUSR2, passing it the listeners of the servers, then shuts down.
+	// SIGUSR2 terminates the process when unset.
+	GracefulRestart bool
+
+	// ReadHeaderTimeout, ReadTimeout, WriteTimeout, IdleTimeout and MaxHeaderBytes are those of http.Server,
+	// zero meaning no limit (the default of MaxHeaderBytes for the latter). Handlers streaming their response
+	// clear their write deadline, see http.ResponseController.
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	// MaxBodyBytes limits the size of the request bodies of the api, no limit when zero.
+	MaxBodyBytes int64
+
+	// TLS configures the TLS termination, see TLSConfig.Enabled.
+	TLS TLSConfig
+
+	// Debug configures the profiling and runtime stats endpoints, served with the ops ones, see OpsAddr.
+	Debug DebugConfig
+
+	// OnShutdown releases the resources of the application once the server stops accepting requests.
+	// It runs along with the shutdown of the server, which waits for it within ShutdownTimeout.
+	OnShutdown func(ctx context.Context)
+
+	// OnReload reloads the configuration of the application when the process receives SIGHUP,
+	// while the server keeps serving on the same listener. SIGHUP terminates the process when unset.
+	OnReload func(ctx context.Context)
 }
 
 type HTTPServer interface {
@@ -23,70 +63,220 @@ type HTTPServer interface {
 }
 
 type server struct {
-	srv *http.Server
-	ln  net.Listener
 	cfg ServerConfig
+	// endpoints are the api one, then the ops one when it has a listener of its own, see ServerConfig.OpsAddr
+	endpoints []*endpoint
+}
+
+// endpoint is an http.Server along with its listener.
+type endpoint struct {
+	srv *http.Server
+	// ln is the listener of srv, rawLn the one passed on at a graceful restart (the same without TLS)
+	ln    net.Listener
+	rawLn net.Listener
+
+	// newConns are the connections accepted whose first request is not read yet, see drain. drained is
+	// closed when none are left once drain started.
+	mu       sync.Mutex
+	newConns map[net.Conn]struct{}
+	drained  chan struct{}
 }
 
 func NewHTTPServer(cfg ServerConfig, api http.Handler) (HTTPServer, error) {
+	if cfg.MaxBodyBytes > 0 {
+		api = http.MaxBytesHandler(api, cfg.MaxBodyBytes)
+	}
+
+	if cfg.Name == "" {
+		cfg.Name = "http"
+	}
 	// start server
-	ln, err := net.Listen("tcp", cfg.Addr)
+	ln, err := listen(cfg)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		return nil, err
 	}
 
-	mux := newRouter(api)
-	srv := &http.Server{Handler: mux}
+	ops := newOpsMux(cfg.Debug)
+	var handler http.Handler = newRouter(api, ops, cfg.OpsAddr == "")
+	var tlsCfg *tls.Config
+	serveLn := ln
+	if cfg.TLS.Enabled() {
+		if tlsCfg, err = newTLSConfig(cfg.TLS); err != nil {
+			listeners.remove(ln)
+			_ = ln.Close()
+			return nil, err
+		}
+		serveLn = tls.NewListener(ln, tlsCfg)
+		handler = clientIdentityMiddleware(handler)
+	}
 
-	// Ensure resources are released when the server shuts down
-	srv.RegisterOnShutdown(func() {
-		if cfg.OnShutdown != nil {
-			cfg.OnShutdown()
+	s := &server{cfg: cfg}
+	s.endpoints = append(s.endpoints, newEndpoint(cfg, handler, tlsCfg, serveLn, ln))
+
+	if cfg.OpsAddr != "" {
+		opsLn, err := listen(ServerConfig{Addr: cfg.OpsAddr, Name: cfg.Name + "-ops"})
+		if err != nil {
+			listeners.remove(ln)
+			_ = ln.Close()
+			return nil, err
 		}
-		log.Println("shutting down server")
-	})
-
-	return &server{
-		cfg: cfg,
-		srv: srv,
-		ln:  ln,
-	}, nil
+		s.endpoints = append(s.endpoints, newEndpoint(cfg, ops, nil, opsLn, opsLn))
+	}
+
+	return s, nil
 }
 
+func newEndpoint(cfg ServerConfig, handler http.Handler, tlsCfg *tls.Config, ln, rawLn net.Listener) *endpoint {
+	e := &endpoint{
+		srv: &http.Server{
+			Handler:           handler,
+			TLSConfig:         tlsCfg,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			ReadTimeout:       cfg.ReadTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		},
+		ln:       ln,
+		rawLn:    rawLn,
+		newConns: make(map[net.Conn]struct{}),
+	}
+	e.srv.ConnState = e.trackNewConn
+	return e
+}
+
+func (e *endpoint) trackNewConn(c net.Conn, state http.ConnState) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if state == http.StateNew {
+		e.newConns[c] = struct{}{}
+		return
+	}
+	delete(e.newConns, c)
+	if e.drained != nil && len(e.newConns) == 0 {
+		close(e.drained)
+		e.drained = nil
+	}
+}
+
+// drain stops accepting connections, then waits for those accepted to send their first request: the shutdown
+// of http.Server drops the connections whose first request is read after it started, e.g. those accepted
+// just before the listener is passed on to the new process at a graceful restart.
+func (e *endpoint) drain(ctx context.Context) {
+	_ = e.ln.Close()
+
+	e.mu.Lock()
+	if len(e.newConns) == 0 {
+		e.mu.Unlock()
+		return
+	}
+	drained := make(chan struct{})
+	e.drained = drained
+	e.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+	case <-drained:
+	}
+}
+
+// Run serves until ctx is done or the process is signaled to stop, then shuts down gracefully within the
+// shutdown timeout. The listeners run and stop together: when one of them fails, the others are shut down.
 func (s *server) Run(ctx context.Context) error {
-	errCh := make(chan error, 1)
-	go func() {
-		log.Printf("server listening on %s", s.cfg.Addr)
-		errCh <- s.srv.Serve(s.ln)
-	}()
+	releaseInherited()
+
+	errCh := make(chan error, len(s.endpoints))
+	for _, e := range s.endpoints {
+		defer listeners.remove(e.rawLn)
+		go func() {
+			log.Printf("server listening on %s", e.ln.Addr())
+			errCh <- e.srv.Serve(e.ln)
+		}()
+	}
+	running := len(s.endpoints)
 
 	// Make a signal context to also react to OS signals
 	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	select {
-	case <-ctx.Done():
-		// proceed to shutdown
-	case <-sigCtx.Done():
-		// proceed to shutdown
-	case err := <-errCh:
-		if err != nil && err != http.ErrServerClosed {
-			return err
+	hup := make(chan os.Signal, 1)
+	if s.cfg.OnReload != nil {
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+	}
+	usr2 := make(chan os.Signal, 1)
+	if s.cfg.GracefulRestart {
+		signal.Notify(usr2, syscall.SIGUSR2)
+		defer signal.Stop(usr2)
+	}
+
+	var runErr error
+wait:
+	for {
+		select {
+		case <-ctx.Done():
+			break wait
+		case <-sigCtx.Done():
+			break wait
+		case <-hup:
+			s.cfg.OnReload(ctx)
+		case <-usr2:
+			pid, err := restart()
+			if err != nil {
+				log.Printf("graceful restart failed, still serving: %v", err)
+				continue
+			}
+			log.Printf("restarted as process %d", pid)
+			break wait
+		case err := <-errCh:
+			runErr = serveError(err)
+			running--
+			break wait
 		}
-		return nil
 	}
 
+	log.Println("shutting down server")
 	sdCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
 	defer cancel()
-	_ = s.srv.Shutdown(sdCtx)
 
-	select {
-	case err := <-errCh:
-		if err != nil && err != http.ErrServerClosed {
-			return err
+	// Ensure resources are released when the server shuts down
+	var wg sync.WaitGroup
+	if s.cfg.OnShutdown != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.cfg.OnShutdown(sdCtx)
+		}()
+	}
+	for _, e := range s.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.drain(sdCtx)
+			_ = e.srv.Shutdown(sdCtx)
+		}()
+	}
+	wg.Wait()
+
+	timeout := time.After(s.cfg.ShutdownTimeout + time.Second)
+	for ; running > 0; running-- {
+		select {
+		case err := <-errCh:
+			if runErr == nil {
+				runErr = serveError(err)
+			}
+		case <-timeout:
+			return context.DeadlineExceeded
 		}
+	}
+	return runErr
+}
+
+// serveError returns the error of http.Server.Serve, nil when it stopped because of the shutdown.
+func serveError(err error) error {
+	if errors.Is(err, http.ErrServerClosed) || errors.Is(err, net.ErrClosed) {
 		return nil
-	case <-time.After(s.cfg.ShutdownTimeout + time.Second):
-		return context.DeadlineExceeded
 	}
+	return err
 }
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// TLSConfig configures the TLS termination of a server, enabled when CertFile is set.
+type TLSConfig struct {
+	// CertFile and KeyFile are the PEM files of the certificate, chain included, and of its private key.
+	// They are checked for changes at most every ReloadInterval, on new connections, and reloaded without
+	// a restart. A failed reload, e.g. of a certificate not matching its key yet, keeps the previous one.
+	CertFile       string
+	KeyFile        string
+	ReloadInterval time.Duration
+	// MinVersion is the minimum TLS version, tls.VersionTLS12 when zero.
+	MinVersion uint16
+	// CipherSuites are the cipher suites of TLS 1.2, the defaults of crypto/tls when empty.
+	// Those of TLS 1.3 are not configurable.
+	CipherSuites []uint16
+	// ClientCAFile enables mutual TLS: it is the PEM file of the CAs signing the certificates of the clients,
+	// whose identity is then available to the handlers, see ClientIdentityFromContext.
+	ClientCAFile string
+	// ClientCertOptional accepts the clients without a certificate; those presenting one are still verified.
+	ClientCertOptional bool
+}
+
+// Enabled reports whether the server terminates TLS.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// newTLSConfig returns the tls.Config of a server, negotiating HTTP/2 with the clients supporting it.
+func newTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	certs, err := newCertificateReloader(cfg.CertFile, cfg.KeyFile, cfg.ReloadInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCfg := &tls.Config{
+		GetCertificate: certs.GetCertificate,
+		MinVersion:     cfg.MinVersion,
+		CipherSuites:   cfg.CipherSuites,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+	if tlsCfg.MinVersion == 0 {
+		tlsCfg.MinVersion = tls.VersionTLS12
+	}
+
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the client CA file: %w", err)
+		}
+		tlsCfg.ClientCAs = x509.NewCertPool()
+		if !tlsCfg.ClientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in the client CA file %s", cfg.ClientCAFile)
+		}
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+		if cfg.ClientCertOptional {
+			tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+
+	return tlsCfg, nil
+}
+
+// certificateReloader serves the certificate of certFile and keyFile, reloading it when either file changes.
+type certificateReloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	versions  [2]fileVersion
+	checkedAt time.Time
+}
+
+// fileVersion tells apart the versions of a file.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+func newCertificateReloader(certFile, keyFile string, interval time.Duration) (*certificateReloader, error) {
+	r := &certificateReloader{certFile: certFile, keyFile: keyFile, interval: interval}
+	versions, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(versions); err != nil {
+		return nil, err
+	}
+	r.checkedAt = time.Now()
+	return r, nil
+}
+
+func (r *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) < r.interval {
+		return r.cert, nil
+	}
+	r.checkedAt = time.Now()
+
+	versions, err := r.stat()
+	if err == nil && versions != r.versions {
+		err = r.load(versions)
+		if err == nil {
+			log.Printf("tls certificate reloaded from %s", r.certFile)
+		}
+	}
+	if err != nil {
+		// tried again at the next check
+		log.Printf("failed to reload the tls certificate, keeping the previous one: %v", err)
+	}
+	return r.cert, nil
+}
+
+func (r *certificateReloader) stat() ([2]fileVersion, error) {
+	var versions [2]fileVersion
+	for i, name := range []string{r.certFile, r.keyFile} {
+		fi, err := os.Stat(name)
+		if err != nil {
+			return versions, fmt.Errorf("failed to read the tls certificate: %w", err)
+		}
+		versions[i] = fileVersion{modTime: fi.ModTime(), size: fi.Size()}
+	}
+	return versions, nil
+}
+
+func (r *certificateReloader) load(versions [2]fileVersion) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the tls certificate: %w", err)
+	}
+	r.cert = &cert
+	r.versions = versions
+	return nil
+}
+
+// ClientIdentity is the identity of a client authenticated by its certificate, with mutual TLS.
+type ClientIdentity struct {
+	// Subject is the distinguished name of the certificate, e.g. "CN=batch-jobs,O=Pokedex".
+	Subject    string
+	CommonName string
+	DNSNames   []string
+	// URIs are the URI names of the certificate, e.g. SPIFFE IDs.
+	URIs         []string
+	SerialNumber string
+}
+
+const clientIdentityKey contextKey = "client_identity"
+
+// ClientIdentityFromContext returns the identity of the client of a request, if it presented a verified certificate.
+func ClientIdentityFromContext(ctx context.Context) (ClientIdentity, bool) {
+	id, ok := ctx.Value(clientIdentityKey).(ClientIdentity)
+	return id, ok
+}
+
+// withClientIdentity returns ctx carrying the identity of the client of the connection in state, if any.
+func withClientIdentity(ctx context.Context, state *tls.ConnectionState) context.Context {
+	if state == nil || len(state.VerifiedChains) == 0 {
+		return ctx
+	}
+
+	cert := state.VerifiedChains[0][0]
+	id := ClientIdentity{
+		Subject:      cert.Subject.String(),
+		CommonName:   cert.Subject.CommonName,
+		DNSNames:     cert.DNSNames,
+		SerialNumber: cert.SerialNumber.String(),
+	}
+	for _, u := range cert.URIs {
+		id.URIs = append(id.URIs, u.String())
+	}
+	return context.WithValue(ctx, clientIdentityKey, id)
+}
+
+func clientIdentityMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(withClientIdentity(r.Context(), r.TLS)))
+	})
+}